@@ -9,7 +9,8 @@ import (
 )
 
 type Token struct {
-	DB schema.BasicDB
+	DB  schema.BasicDB
+	Now int64 // timestamp (UnixMilli) of the message being applied
 }
 
 func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
@@ -32,6 +33,18 @@ func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
 		err = schema.ErrInvalidMintOwner // Reuse error type for now
 		return
 	}
+
+	// Parse and validate ComplianceOwner with default value
+	complianceOwnerStr := env.Meta.Params["ComplianceOwner"]
+	if complianceOwnerStr == "" {
+		complianceOwnerStr = env.Meta.AccId // Default to owner
+	}
+	_, complianceOwner, err := utils.IDCheck(complianceOwnerStr)
+	if err != nil {
+		err = schema.ErrInvalidComplianceOwner
+		return
+	}
+
 	maxSupplyStr := env.Meta.Params["MaxSupply"]
 	if mintOwnerStr == "" {
 		mintOwnerStr = "0"
@@ -42,6 +55,14 @@ func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
 		return
 	}
 
+	// Parse and validate the optional transfer policy
+	var policy schema.Policy
+	if policyStr := env.Meta.Params["Policy"]; policyStr != "" {
+		if policy, err = ParsePolicy(policyStr); err != nil {
+			return
+		}
+	}
+
 	db := cache.NewBasicToken(schema.Info{
 		Id:          env.Meta.ItemId,
 		Name:        env.Meta.Params["Name"],
@@ -49,11 +70,22 @@ func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
 		Decimals:    env.Meta.Params["Decimals"],
 		Logo:        env.Meta.Params["Logo"],
 		Description: env.Meta.Params["Description"],
-	}, env.Meta.AccId, mintOwner, maxSupply)
+	}, env.Meta.AccId, mintOwner, complianceOwner, maxSupply)
+	db.SetPolicy(policy)
+
+	// Parse and validate the optional emission schedule
+	if emissionStr := env.Meta.Params["Emission"]; emissionStr != "" {
+		emission, err := ParseEmission(emissionStr, env.Meta.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		db.SetEmission(emission)
+	}
 	return &Token{DB: db}, nil
 }
 
 func (b *Token) Apply(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	b.Now = meta.Timestamp
 	switch meta.Action {
 	case "Info":
 		res = b.handleInfo(from)
@@ -64,10 +96,61 @@ func (b *Token) Apply(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
 	case "Balance":
 		res = b.HandleBalanceOf(from, meta.Params)
 	case "Transfer":
-		res = b.HandleTransfer(meta.ItemId, from, meta.Params)
+		res = b.Idempotent(from, meta, func() vmmSchema.Result {
+			return b.HandleTransfer(meta.ItemId, from, meta.Params)
+		})
 	case "Mint":
-		res = b.handleMint(from, meta.Params)
+		res = b.Idempotent(from, meta, func() vmmSchema.Result {
+			return b.handleMint(from, meta.Params)
+		})
+	case "Approve-Hold":
+		res = b.HandleApproveHold(from, meta.Params)
+	case "Hold":
+		res = b.HandleHold(meta.ItemId, from, meta.Params)
+	case "Capture-Hold":
+		res = b.HandleCaptureHold(meta.ItemId, from, meta.Params)
+	case "Release-Hold":
+		res = b.HandleReleaseHold(from, meta.Params)
+	case "Freeze-Account":
+		res = b.HandleFreezeAccount(meta.ItemId, from, meta.Params, true)
+	case "Unfreeze-Account":
+		res = b.HandleFreezeAccount(meta.ItemId, from, meta.Params, false)
+	case "Force-Transfer":
+		res = b.HandleForceTransfer(meta.ItemId, from, meta.Params)
+	case "Is-Frozen":
+		res = b.HandleIsFrozen(from, meta.Params)
+	case "Set-Policy":
+		res = b.HandleSetPolicy(from, meta.Params)
+	case "Policy":
+		res = b.HandlePolicy(from)
+	case "Redenominate":
+		res = b.HandleRedenominate(meta.ItemId, from, meta.Params)
+	case "Approve-Redenominate":
+		res = b.HandleApproveRedenominate(meta.ItemId, from, meta.Params)
+	case "Distribute":
+		res = b.HandleDistribute(from, meta.Params)
+	case "Credit-Notice":
+		res = b.HandleDistributeCredit(from, meta.Params)
+	case "Claim-Rewards":
+		res = b.HandleClaimRewards(meta.ItemId, from, meta.Params)
+	case "Rewards":
+		res = b.HandleRewards(from, meta.Params)
+	case "Set-Reward-Exclusions":
+		res = b.HandleSetRewardExclusions(from, meta.Params)
+	case "Set-Reward-Tokens":
+		res = b.HandleSetRewardTokens(from, meta.Params)
+	case "TWAB":
+		res = b.HandleTwab(from, meta.Params)
+	case "Total-Supply-TWAB":
+		res = b.HandleTotalSupplyTwab(from, meta.Params)
+	case "Emit":
+		res = b.HandleEmit(from)
+	case "Set-Emission":
+		res = b.HandleSetEmission(from, meta.Params)
 	}
+
+	// Any successful message mints the accrued emission
+	b.AutoEmit(&res)
 	return
 }
 
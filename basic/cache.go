@@ -18,6 +18,9 @@ func (b *Token) initCache() (cache map[string]string) {
 	maps.Copy(cache, b.CacheBalances())
 	maps.Copy(cache, b.CacheTotalSupply())
 	maps.Copy(cache, b.cacheTokenInfo())
+	maps.Copy(cache, b.CacheFrozen())
+	maps.Copy(cache, b.CachePolicy())
+	maps.Copy(cache, b.CacheEmission())
 	return
 }
 
@@ -32,6 +35,8 @@ func (b *Token) cacheTokenInfo() map[string]string {
 		Owner:       b.DB.Owner(),
 		MintOwner:   b.DB.MintOwner(),
 		MaxSupply:   b.DB.MaxSupply().String(),
+
+		ComplianceOwner: b.DB.ComplianceOwner(),
 	}
 	res, _ := json.Marshal(cacheInfo)
 	return map[string]string{
@@ -85,3 +90,35 @@ func (b *Token) CacheBalances() map[string]string {
 	}
 	return cacheMap
 }
+
+func (b *Token) CacheFrozen() map[string]string {
+	frozenBy, _ := json.Marshal(b.DB.FrozenAccounts())
+	return map[string]string{
+		"frozen": string(frozenBy),
+	}
+}
+
+func (b *Token) CachePolicy() map[string]string {
+	policyBy, _ := json.Marshal(b.DB.Policy())
+	return map[string]string{
+		"policy": string(policyBy),
+	}
+}
+
+func (b *Token) CacheEmission() map[string]string {
+	emission, ok := b.DB.Emission()
+	if !ok {
+		return map[string]string{
+			"emission": "{}",
+		}
+	}
+	emitted, lastEmission := b.DB.EmissionProgress()
+	emissionBy, _ := json.Marshal(schema.EmissionCacheInfo{
+		Emission:     emission,
+		Emitted:      emitted.String(),
+		LastEmission: lastEmission,
+	})
+	return map[string]string{
+		"emission": string(emissionBy),
+	}
+}
@@ -0,0 +1,205 @@
+package basic
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// HandleFreezeAccount freezes or unfreezes an account (ComplianceOwner only)
+func (b *Token) HandleFreezeAccount(itemId, from string, params map[string]string, frozen bool) (res vmmSchema.Result) {
+	if from != b.DB.ComplianceOwner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	// Parse and validate account
+	account := params["Account"]
+	if account == "" {
+		res.Error = schema.ErrMissingAccount
+		return
+	}
+	_, account, err := utils.IDCheck(account)
+	if err != nil {
+		res.Error = schema.ErrInvalidAccount
+		return
+	}
+
+	reason := params["Reason"]
+	if reason == "" {
+		res.Error = schema.ErrMissingReason
+		return
+	}
+
+	action := "Freeze-Account"
+	notice := "Freeze-Notice"
+	if !frozen {
+		action = "Unfreeze-Account"
+		notice = "Unfreeze-Notice"
+	}
+
+	b.DB.SetFrozen(account, frozen)
+	b.DB.AppendAuditRecord(schema.AuditRecord{
+		Action:    action,
+		Operator:  from,
+		Account:   account,
+		Reason:    reason,
+		ItemId:    itemId,
+		Timestamp: b.Now,
+	})
+
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: notice},
+		{Name: "Account", Value: account},
+		{Name: "Reason", Value: reason},
+		{Name: "Ticker", Value: b.DB.Info().Ticker},
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{Target: from, Tags: tags},
+		{Target: account, Tags: tags},
+	}
+	res.Cache = b.CacheFrozen()
+	return
+}
+
+// HandleForceTransfer moves tokens out of an account regardless of freezes and holds (ComplianceOwner only)
+func (b *Token) HandleForceTransfer(itemId, from string, params map[string]string) (res vmmSchema.Result) {
+	if from != b.DB.ComplianceOwner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	// Parse and validate the account to claw back from
+	account := params["Account"]
+	if account == "" {
+		res.Error = schema.ErrMissingAccount
+		return
+	}
+	_, account, err := utils.IDCheck(account)
+	if err != nil {
+		res.Error = schema.ErrInvalidAccount
+		return
+	}
+
+	// Parse and validate recipient
+	recipient, exists := params["Recipient"]
+	if !exists {
+		res.Error = schema.ErrMissingRecipient
+		return
+	}
+	_, recipient, err = utils.IDCheck(recipient)
+	if err != nil {
+		res.Error = schema.ErrInvalidRecipient
+		return
+	}
+
+	// Parse and validate quantity
+	quantity, exists := params["Quantity"]
+	if !exists {
+		res.Error = schema.ErrMissingQuantity
+		return
+	}
+	amount, ok := new(big.Int).SetString(quantity, 10)
+	if !ok {
+		res.Error = schema.ErrInvalidQuantityFormat
+		return
+	}
+	if amount.Sign() <= 0 {
+		res.Error = schema.ErrIncorrectQuantity
+		return
+	}
+
+	reason := params["Reason"]
+	if reason == "" {
+		res.Error = schema.ErrMissingReason
+		return
+	}
+
+	if err = b.ForceTransfer(account, recipient, amount); err != nil {
+		res.Error = err
+		return
+	}
+
+	b.DB.AppendAuditRecord(schema.AuditRecord{
+		Action:    "Force-Transfer",
+		Operator:  from,
+		Account:   account,
+		Recipient: recipient,
+		Quantity:  quantity,
+		Reason:    reason,
+		ItemId:    itemId,
+		Timestamp: b.Now,
+	})
+
+	complianceNotice := &vmmSchema.ResMessage{
+		Target: from,
+		Tags: []goarSchema.Tag{
+			{Name: "Action", Value: "Force-Transfer-Notice"},
+			{Name: "Account", Value: account},
+			{Name: "Recipient", Value: recipient},
+			{Name: "Quantity", Value: quantity},
+			{Name: "Reason", Value: reason},
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+	debitNotice := &vmmSchema.ResMessage{
+		Target: account,
+		Data:   quantity + " was force transferred to " + recipient,
+		Tags: []goarSchema.Tag{
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "Action", Value: "Debit-Notice"},
+			{Name: "Recipient", Value: recipient},
+			{Name: "Quantity", Value: quantity},
+			{Name: "Reason", Value: reason},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+	creditNotice := &vmmSchema.ResMessage{
+		Target: recipient,
+		Data:   "You received " + quantity + " from " + account,
+		Tags: []goarSchema.Tag{
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "Action", Value: "Credit-Notice"},
+			{Name: "Sender", Value: account},
+			{Name: "Quantity", Value: quantity},
+			{Name: "Reason", Value: reason},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+
+	res.Messages = []*vmmSchema.ResMessage{complianceNotice, debitNotice, creditNotice}
+	res.Cache = b.CacheChangeBalance(account, recipient)
+	return
+}
+
+func (b *Token) HandleIsFrozen(from string, params map[string]string) (res vmmSchema.Result) {
+	// Determine account to query (default to sender if not specified)
+	accountId := from
+	if account := params["Account"]; account != "" {
+		accountId = account
+	}
+	_, accountId, err := utils.IDCheck(accountId)
+	if err != nil {
+		res.Error = schema.ErrInvalidAccount
+		return
+	}
+
+	frozen := strconv.FormatBool(b.DB.IsFrozen(accountId))
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   frozen,
+			Tags: []goarSchema.Tag{
+				{Name: "Frozen", Value: frozen},
+				{Name: "Account", Value: accountId},
+				{Name: "Ticker", Value: b.DB.Info().Ticker},
+			},
+		},
+	}
+	return
+}
@@ -0,0 +1,229 @@
+package basic
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// DefaultEmissionPeriod is the period of an emission rate given without Period (UnixMilli)
+const DefaultEmissionPeriod = int64(24 * 60 * 60 * 1000)
+
+// ParseEmission strictly decodes and normalizes an emission schedule, an empty document returns nil.
+// Start defaults to now.
+func ParseEmission(emissionStr string, now int64) (*schema.Emission, error) {
+	emission := &schema.Emission{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(emissionStr)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(emission); err != nil {
+		return nil, schema.ErrInvalidEmission
+	}
+	if *emission == (schema.Emission{}) {
+		return nil, nil
+	}
+
+	_, recipient, err := utils.IDCheck(emission.Recipient)
+	if err != nil {
+		return nil, schema.ErrInvalidEmission
+	}
+	emission.Recipient = recipient
+
+	if rate, ok := new(big.Int).SetString(emission.Rate, 10); !ok || rate.Sign() <= 0 {
+		return nil, schema.ErrInvalidEmission
+	}
+	if emission.Period == 0 {
+		emission.Period = DefaultEmissionPeriod
+	}
+	if emission.Start == 0 {
+		emission.Start = now
+	}
+	if emission.Period < 0 || emission.Start < 0 || emission.HalvingInterval < 0 ||
+		(emission.End != 0 && emission.End <= emission.Start) {
+		return nil, schema.ErrInvalidEmission
+	}
+	return emission, nil
+}
+
+// emissionScheduled returns the amount scheduled from the start up to a timestamp
+func emissionScheduled(emission schema.Emission, timestamp int64) *big.Int {
+	if emission.End > 0 && timestamp > emission.End {
+		timestamp = emission.End
+	}
+	scheduled := big.NewInt(0)
+	if timestamp <= emission.Start {
+		return scheduled
+	}
+
+	rate, _ := new(big.Int).SetString(emission.Rate, 10)
+	period := big.NewInt(emission.Period)
+	elapsed := timestamp - emission.Start
+	if emission.HalvingInterval == 0 {
+		scheduled.Mul(rate, big.NewInt(elapsed))
+		return scheduled.Quo(scheduled, period)
+	}
+
+	// Sum every halving step until the elapsed time or the rate is exhausted
+	for elapsed > 0 && rate.Sign() > 0 {
+		duration := min(elapsed, emission.HalvingInterval)
+		amount := new(big.Int).Mul(rate, big.NewInt(duration))
+		scheduled.Add(scheduled, amount.Quo(amount, period))
+		elapsed -= duration
+		rate.Rsh(rate, 1)
+	}
+	return scheduled
+}
+
+// emit mints the amount accrued by the emission schedule since the last emission.
+// Only the minted amount is marked as emitted, the part above MaxSupply stays accrued.
+func (b *Token) emit() (amount *big.Int, err error) {
+	amount = big.NewInt(0)
+	emission, ok := b.DB.Emission()
+	if !ok {
+		return amount, schema.ErrMissingEmission
+	}
+	emitted, lastEmission := b.DB.EmissionProgress()
+	if lastEmission >= b.Now {
+		return
+	}
+	scheduled := emissionScheduled(emission, b.Now)
+	accrued := new(big.Int).Sub(scheduled, emitted)
+	if accrued.Sign() <= 0 {
+		return
+	}
+
+	amount.Set(accrued)
+	if maxSupply := b.DB.MaxSupply(); maxSupply.Sign() > 0 {
+		available := new(big.Int).Sub(maxSupply, b.DB.GetTotalSupply())
+		if available.Sign() < 0 {
+			available.SetInt64(0)
+		}
+		if amount.Cmp(available) > 0 {
+			amount.Set(available)
+		}
+	}
+	if amount.Sign() > 0 {
+		if err = b.Mint(emission.Recipient, amount); err != nil {
+			return big.NewInt(0), err
+		}
+	}
+	b.DB.SetEmissionProgress(emitted.Add(emitted, amount), b.Now)
+	return
+}
+
+// pendingEmission returns the amount accrued by the emission schedule that hasn't been minted yet
+func (b *Token) pendingEmission() *big.Int {
+	emission, _ := b.DB.Emission()
+	emitted, _ := b.DB.EmissionProgress()
+	pending := new(big.Int).Sub(emissionScheduled(emission, b.Now), emitted)
+	if pending.Sign() < 0 {
+		pending.SetInt64(0)
+	}
+	return pending
+}
+
+func (b *Token) emissionResult(amount *big.Int) (res vmmSchema.Result) {
+	emission, _ := b.DB.Emission()
+	emitted, _ := b.DB.EmissionProgress()
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: emission.Recipient,
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Emission-Notice"},
+				{Name: "Recipient", Value: emission.Recipient},
+				{Name: "Quantity", Value: amount.String()},
+				{Name: "Emitted", Value: emitted.String()},
+				{Name: "Pending", Value: b.pendingEmission().String()},
+				{Name: "Ticker", Value: b.DB.Info().Ticker},
+			},
+		},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, b.CacheChangeBalance(emission.Recipient))
+	maps.Copy(res.Cache, b.CacheTotalSupply())
+	maps.Copy(res.Cache, b.CacheEmission())
+	return
+}
+
+// AutoEmit mints the accrued emission along with a successful message.
+// An emission that can't be minted stays accrued for a later message.
+func (b *Token) AutoEmit(res *vmmSchema.Result) {
+	if res.Error != nil {
+		return
+	}
+	if _, ok := b.DB.Emission(); !ok {
+		return
+	}
+	amount, err := b.emit()
+	if err != nil || amount.Sign() == 0 {
+		return
+	}
+
+	emissionRes := b.emissionResult(amount)
+	res.Messages = append(res.Messages, emissionRes.Messages...)
+	if res.Cache == nil {
+		res.Cache = map[string]string{}
+	}
+	maps.Copy(res.Cache, emissionRes.Cache)
+}
+
+// HandleEmit mints the accrued emission, anyone can trigger it
+func (b *Token) HandleEmit(from string) (res vmmSchema.Result) {
+	amount, err := b.emit()
+	if err != nil {
+		res.Error = err
+		return
+	}
+	res = b.emissionResult(amount)
+	if from != res.Messages[0].Target {
+		notice := *res.Messages[0]
+		notice.Target = from
+		res.Messages = append(res.Messages, &notice)
+	}
+	return
+}
+
+// HandleSetEmission replaces the emission schedule (MintOwner only), an empty document removes it.
+// The emission accrued under the previous schedule is minted first when possible.
+func (b *Token) HandleSetEmission(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != b.DB.MintOwner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	emissionStr, exists := params["Emission"]
+	if !exists || emissionStr == "" {
+		res.Error = schema.ErrInvalidEmission
+		return
+	}
+	emission, err := ParseEmission(emissionStr, b.Now)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	// A previous emission that can't be minted, e.g. to a frozen recipient or above MaxSupply, is dropped
+	if _, ok := b.DB.Emission(); ok {
+		_, _ = b.emit()
+	}
+	b.DB.SetEmission(emission)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Set-Emission-Notice", Value: "success"},
+			},
+		},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, b.CacheBalances())
+	maps.Copy(res.Cache, b.CacheTotalSupply())
+	maps.Copy(res.Cache, b.CacheEmission())
+	return
+}
@@ -27,6 +27,7 @@ func (b *Token) handleInfo(from string) (res vmmSchema.Result) {
 				{Name: "Owner", Value: b.DB.Owner()},
 				{Name: "MintOwner", Value: b.DB.MintOwner()},
 				{Name: "MaxSupply", Value: b.DB.MaxSupply().String()},
+				{Name: "ComplianceOwner", Value: b.DB.ComplianceOwner()},
 			},
 			Data: string(c),
 		},
@@ -59,6 +60,28 @@ func (b *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema
 		b.DB.SetMintOwner(newOwner)
 	}
 
+	if meta.Params["ComplianceOwner"] != "" {
+		_, newOwner, err := utils.IDCheck(meta.Params["ComplianceOwner"])
+		if err != nil {
+			res.Error = schema.ErrInvalidComplianceOwner
+			return
+		}
+		b.DB.SetComplianceOwner(newOwner)
+	}
+
+	signersStr, thresholdStr := meta.Params["RedenominateSigners"], meta.Params["RedenominateThreshold"]
+	if signersStr != "" || thresholdStr != "" {
+		signers, threshold := b.DB.RedenominateMultisig()
+		signers, threshold, err := parseRedenominateMultisig(signersStr, thresholdStr, signers, threshold)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		b.DB.SetRedenominateMultisig(signers, threshold)
+		// Approvals were given by the previous signers
+		b.DB.SetPendingRedenomination(nil)
+	}
+
 	info := b.DB.Info()
 	if meta.Params["Name"] != "" {
 		info.Name = meta.Params["Name"]
@@ -133,6 +156,7 @@ func (b *Token) HandleBalanceOf(from string, params map[string]string) (res vmmS
 			Data:   balance.String(),
 			Tags: []goarSchema.Tag{
 				{Name: "Balance", Value: balance.String()},
+				{Name: "Held", Value: b.HeldOf(accountId).String()},
 				{Name: "Ticker", Value: b.DB.Info().Ticker},
 				{Name: "Account", Value: accountId},
 			},
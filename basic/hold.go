@@ -0,0 +1,335 @@
+package basic
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// DefaultHoldDuration is the lifetime of a hold placed without ExpiresAt (UnixMilli)
+const DefaultHoldDuration = int64(7 * 24 * 60 * 60 * 1000)
+
+// HeldOf returns the amount of an account that is reserved by unexpired holds
+func (b *Token) HeldOf(accId string) *big.Int {
+	held := big.NewInt(0)
+	_, accId, err := utils.IDCheck(accId)
+	if err != nil {
+		return held
+	}
+	for _, hold := range b.DB.HoldsOf(accId) {
+		if b.holdExpired(hold) {
+			continue
+		}
+		held.Add(held, hold.Quantity)
+	}
+	return held
+}
+
+func (b *Token) holdExpired(hold schema.Hold) bool {
+	return hold.ExpiresAt > 0 && hold.ExpiresAt <= b.Now
+}
+
+func (b *Token) HandleApproveHold(from string, params map[string]string) (res vmmSchema.Result) {
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+
+	// Parse and validate merchant
+	merchant, exists := params["Merchant"]
+	if !exists || merchant == "" {
+		res.Error = schema.ErrMissingMerchant
+		return
+	}
+	_, merchant, err = utils.IDCheck(merchant)
+	if err != nil {
+		res.Error = schema.ErrInvalidMerchant
+		return
+	}
+
+	// Parse and validate quantity, zero revokes the allowance
+	quantity, exists := params["Quantity"]
+	if !exists {
+		res.Error = schema.ErrMissingQuantity
+		return
+	}
+	amount, ok := new(big.Int).SetString(quantity, 10)
+	if !ok {
+		res.Error = schema.ErrInvalidQuantityFormat
+		return
+	}
+	if amount.Sign() < 0 {
+		res.Error = schema.ErrIncorrectQuantity
+		return
+	}
+
+	b.DB.SetHoldAllowance(from, merchant, amount)
+
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Approve-Hold-Notice"},
+		{Name: "Account", Value: from},
+		{Name: "Merchant", Value: merchant},
+		{Name: "Quantity", Value: quantity},
+		{Name: "Ticker", Value: b.DB.Info().Ticker},
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{Target: from, Tags: tags},
+		{Target: merchant, Tags: tags},
+	}
+	return
+}
+
+func (b *Token) HandleHold(itemId, from string, params map[string]string) (res vmmSchema.Result) {
+	_, merchant, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+
+	// Parse and validate the account to place the hold on
+	account, exists := params["Account"]
+	if !exists || account == "" {
+		res.Error = schema.ErrMissingAccount
+		return
+	}
+	_, account, err = utils.IDCheck(account)
+	if err != nil {
+		res.Error = schema.ErrInvalidAccount
+		return
+	}
+
+	// Parse and validate quantity
+	quantity, exists := params["Quantity"]
+	if !exists {
+		res.Error = schema.ErrMissingQuantity
+		return
+	}
+	amount, ok := new(big.Int).SetString(quantity, 10)
+	if !ok {
+		res.Error = schema.ErrInvalidQuantityFormat
+		return
+	}
+	if amount.Sign() <= 0 {
+		res.Error = schema.ErrIncorrectQuantity
+		return
+	}
+
+	// Parse expiration with default value
+	expiresAt := b.Now + DefaultHoldDuration
+	if expiresAtStr := params["ExpiresAt"]; expiresAtStr != "" {
+		expiresAt, err = strconv.ParseInt(expiresAtStr, 10, 64)
+		if err != nil || expiresAt <= b.Now {
+			res.Error = schema.ErrInvalidExpiresAt
+			return
+		}
+	}
+
+	// Customer approval is required through a hold allowance
+	allowance := b.DB.HoldAllowance(account, merchant)
+	if allowance.Cmp(amount) < 0 {
+		res.Error = schema.ErrInsufficientHoldAllowance
+		return
+	}
+
+	// Expired holds no longer reserve funds, drop them so the hold state stays bounded
+	b.DB.PruneExpiredHolds(b.Now)
+
+	// Only spendable balance can be held
+	balance, err := b.DB.BalanceOf(account)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if new(big.Int).Sub(balance, b.HeldOf(account)).Cmp(amount) < 0 {
+		res.Error = schema.ErrInsufficientBalance
+		return
+	}
+
+	b.DB.SetHoldAllowance(account, merchant, new(big.Int).Sub(allowance, amount))
+	b.DB.SetHold(schema.Hold{
+		Id:        itemId,
+		Account:   account,
+		Merchant:  merchant,
+		Quantity:  amount,
+		ExpiresAt: expiresAt,
+	})
+
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Hold-Notice"},
+		{Name: "HoldId", Value: itemId},
+		{Name: "Account", Value: account},
+		{Name: "Merchant", Value: merchant},
+		{Name: "Quantity", Value: quantity},
+		{Name: "ExpiresAt", Value: strconv.FormatInt(expiresAt, 10)},
+		{Name: "Ticker", Value: b.DB.Info().Ticker},
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{Target: merchant, Tags: tags},
+		{Target: account, Tags: tags},
+	}
+	return
+}
+
+func (b *Token) HandleCaptureHold(itemId, from string, params map[string]string) (res vmmSchema.Result) {
+	hold, err := b.merchantHold(from, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if b.holdExpired(hold) {
+		res.Error = schema.ErrHoldExpired
+		return
+	}
+
+	// Capture the full hold unless a partial quantity is given
+	amount := new(big.Int).Set(hold.Quantity)
+	if quantity := params["Quantity"]; quantity != "" {
+		var ok bool
+		amount, ok = new(big.Int).SetString(quantity, 10)
+		if !ok {
+			res.Error = schema.ErrInvalidQuantityFormat
+			return
+		}
+		if amount.Sign() <= 0 || amount.Cmp(hold.Quantity) > 0 {
+			res.Error = schema.ErrIncorrectQuantity
+			return
+		}
+	}
+
+	// Captured funds go to the merchant unless a recipient is given
+	recipient := hold.Merchant
+	if params["Recipient"] != "" {
+		_, recipient, err = utils.IDCheck(params["Recipient"])
+		if err != nil {
+			res.Error = schema.ErrInvalidRecipient
+			return
+		}
+	}
+
+	// Closing the hold releases its reservation, the uncaptured remainder stays with the account
+	b.DB.DeleteHold(hold.Id)
+	if err = b.Transfer(hold.Account, recipient, amount); err != nil {
+		b.DB.SetHold(hold)
+		res.Error = err
+		return
+	}
+	b.DB.PruneExpiredHolds(b.Now)
+
+	released := new(big.Int).Sub(hold.Quantity, amount)
+	captureNotice := &vmmSchema.ResMessage{
+		Target: hold.Merchant,
+		Tags: []goarSchema.Tag{
+			{Name: "Action", Value: "Capture-Notice"},
+			{Name: "HoldId", Value: hold.Id},
+			{Name: "Account", Value: hold.Account},
+			{Name: "Recipient", Value: recipient},
+			{Name: "Quantity", Value: amount.String()},
+			{Name: "Released", Value: released.String()},
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+	debitNotice := &vmmSchema.ResMessage{
+		Target: hold.Account,
+		Data:   "Hold " + hold.Id + " captured " + amount.String() + " to " + recipient,
+		Tags: []goarSchema.Tag{
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "Action", Value: "Debit-Notice"},
+			{Name: "Recipient", Value: recipient},
+			{Name: "Quantity", Value: amount.String()},
+			{Name: "HoldId", Value: hold.Id},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+	creditNotice := &vmmSchema.ResMessage{
+		Target: recipient,
+		Data:   "You received " + amount.String() + " from " + hold.Account,
+		Tags: []goarSchema.Tag{
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "Action", Value: "Credit-Notice"},
+			{Name: "Sender", Value: hold.Account},
+			{Name: "Quantity", Value: amount.String()},
+			{Name: "HoldId", Value: hold.Id},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+
+	res.Messages = []*vmmSchema.ResMessage{captureNotice, debitNotice, creditNotice}
+	res.Cache = b.CacheChangeBalance(hold.Account, recipient)
+	return
+}
+
+func (b *Token) HandleReleaseHold(from string, params map[string]string) (res vmmSchema.Result) {
+	holdId := params["HoldId"]
+	if holdId == "" {
+		res.Error = schema.ErrMissingHoldId
+		return
+	}
+	hold, ok := b.DB.GetHold(holdId)
+	if !ok {
+		res.Error = schema.ErrHoldNotFound
+		return
+	}
+
+	// The merchant can release at any time, the account only once the hold has expired
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+	if from != hold.Merchant {
+		if from != hold.Account {
+			res.Error = schema.ErrIncorrectMerchant
+			return
+		}
+		if !b.holdExpired(hold) {
+			res.Error = schema.ErrHoldNotExpired
+			return
+		}
+	}
+
+	b.DB.DeleteHold(hold.Id)
+
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Release-Notice"},
+		{Name: "HoldId", Value: hold.Id},
+		{Name: "Account", Value: hold.Account},
+		{Name: "Merchant", Value: hold.Merchant},
+		{Name: "Quantity", Value: hold.Quantity.String()},
+		{Name: "Ticker", Value: b.DB.Info().Ticker},
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{Target: hold.Merchant, Tags: tags},
+		{Target: hold.Account, Tags: tags},
+	}
+	return
+}
+
+// merchantHold loads the hold referenced by HoldId and checks it belongs to the sending merchant
+func (b *Token) merchantHold(from string, params map[string]string) (hold schema.Hold, err error) {
+	holdId := params["HoldId"]
+	if holdId == "" {
+		err = schema.ErrMissingHoldId
+		return
+	}
+	hold, ok := b.DB.GetHold(holdId)
+	if !ok {
+		err = schema.ErrHoldNotFound
+		return
+	}
+	_, from, err = utils.IDCheck(from)
+	if err != nil {
+		err = schema.ErrInvalidFrom
+		return
+	}
+	if from != hold.Merchant {
+		err = schema.ErrIncorrectMerchant
+		return
+	}
+	return
+}
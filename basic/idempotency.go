@@ -0,0 +1,100 @@
+package basic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+const (
+	// IdempotencyKeyTTL is how long an X-Idempotency-Key is remembered (UnixMilli)
+	IdempotencyKeyTTL = int64(24 * 60 * 60 * 1000)
+	// IdempotencyMaxKeys bounds the remembered keys of a sender, new keys are rejected until older ones expire
+	IdempotencyMaxKeys = 1000
+	// IdempotencyMaxTotalKeys bounds the remembered keys of all senders together
+	IdempotencyMaxTotalKeys = 100000
+)
+
+// idempotencyParams are the params a reused X-Idempotency-Key must repeat. Transport tags such as
+// SDK-Timestamp differ between retries, so they are left out.
+var idempotencyParams = []string{
+	"Recipient", "X-Recipient", "Quantity",
+	"SourceChainType", "SourceTokenId", "X-MintTxHash", "X-BlockHeight",
+	"TargetChainType", "TargetTokenId",
+}
+
+// idempotencyParamsHash returns the hex SHA-256 of the idempotency params of a message
+func idempotencyParamsHash(params map[string]string) string {
+	hash := sha256.New()
+	for _, name := range idempotencyParams {
+		value, exists := params[name]
+		if !exists {
+			continue
+		}
+		hash.Write([]byte(name + "\x00" + value + "\x00"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Idempotent applies a message at most once per sender and X-Idempotency-Key.
+// A repeated key returns the messages of the original result that were addressed to the sender,
+// failed messages are not recorded so they can be retried.
+func (b *Token) Idempotent(from string, meta vmmSchema.Meta, apply func() vmmSchema.Result) (res vmmSchema.Result) {
+	key := meta.Params["X-Idempotency-Key"]
+	if key == "" {
+		return apply()
+	}
+	_, sender, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+
+	b.DB.PruneIdempotencyRecords(b.Now - IdempotencyKeyTTL)
+	paramsHash := idempotencyParamsHash(meta.Params)
+	if record, ok := b.DB.IdempotencyRecord(sender, key); ok {
+		// Records restored from older checkpoints have no params hash
+		if record.Action != meta.Action || (record.ParamsHash != "" && record.ParamsHash != paramsHash) {
+			res.Error = schema.ErrIdempotencyKeyReused
+			return
+		}
+		_ = json.Unmarshal([]byte(record.Messages), &res.Messages)
+		for _, msg := range res.Messages {
+			msg.Tags = append(msg.Tags, goarSchema.Tag{Name: "Idempotent-Replay", Value: "true"})
+		}
+		return
+	}
+
+	// Live keys are never evicted, a retry after an eviction would be applied twice
+	if b.DB.IdempotencyKeyCount(sender) >= IdempotencyMaxKeys || b.DB.IdempotencyKeyTotal() >= IdempotencyMaxTotalKeys {
+		res.Error = schema.ErrTooManyIdempotencyKeys
+		return
+	}
+
+	res = apply()
+	if res.Error != nil {
+		return
+	}
+
+	senderMessages := make([]*vmmSchema.ResMessage, 0)
+	for _, msg := range res.Messages {
+		if _, target, err := utils.IDCheck(msg.Target); err == nil && target == sender {
+			senderMessages = append(senderMessages, msg)
+		}
+	}
+	messagesBy, _ := json.Marshal(senderMessages)
+	b.DB.AddIdempotencyRecord(schema.IdempotencyRecord{
+		Sender:     sender,
+		Key:        key,
+		Action:     meta.Action,
+		ParamsHash: paramsHash,
+		Timestamp:  b.Now,
+		Messages:   string(messagesBy),
+	})
+	return
+}
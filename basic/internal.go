@@ -14,13 +14,22 @@ func (b *Token) Mint(to string, amount *big.Int) (err error) {
 		return
 	}
 
+	// Frozen accounts can't receive
+	if b.DB.IsFrozen(to) {
+		return schema.ErrAccountFrozen
+	}
+
+	if err = b.checkMintPolicy(to, amount); err != nil {
+		return
+	}
+
 	// Add tokens to recipient
 	if err = b.Add(to, amount); err != nil {
 		return
 	}
 
 	// Increase total supply
-	b.DB.SetTotalSupply(new(big.Int).Add(b.DB.GetTotalSupply(), amount))
+	b.SetTotalSupply(new(big.Int).Add(b.DB.GetTotalSupply(), amount))
 	return
 }
 
@@ -31,6 +40,15 @@ func (b *Token) Transfer(from, to string, amount *big.Int) (err error) {
 		return
 	}
 
+	// Frozen accounts can neither send nor receive
+	if b.DB.IsFrozen(from) || b.DB.IsFrozen(to) {
+		return schema.ErrAccountFrozen
+	}
+
+	if err = b.checkTransferPolicy(to, amount); err != nil {
+		return
+	}
+
 	// Deduct tokens from sender
 	if err = b.Sub(from, amount); err != nil {
 		return
@@ -44,23 +62,89 @@ func (b *Token) Transfer(from, to string, amount *big.Int) (err error) {
 	return nil
 }
 
+// ForceTransfer moves tokens ignoring account freezes and holds. The holds of from are shrunk to its
+// remaining balance, and the debit is restored when the credit fails.
+func (b *Token) ForceTransfer(from, to string, amount *big.Int) (err error) {
+	currentBalance, err := b.DB.BalanceOf(from)
+	if err != nil {
+		return
+	}
+	if currentBalance.Cmp(amount) < 0 {
+		return schema.ErrInsufficientBalance
+	}
+	if err = b.settleRewards(from); err != nil {
+		return
+	}
+	newBalance := new(big.Int).Sub(currentBalance, amount)
+	b.observeBalance(from, currentBalance, newBalance)
+	if err = b.DB.UpdateBalance(from, newBalance); err != nil {
+		return
+	}
+	if err = b.Add(to, amount); err != nil {
+		if restoreErr := b.RestoreBalance(from, currentBalance); restoreErr != nil {
+			return restoreErr
+		}
+		return
+	}
+	b.capHolds(from, newBalance)
+	return nil
+}
+
+// RestoreBalance sets back a balance read before an operation, ignoring freezes and holds,
+// e.g. to roll back a credit when a later step of the operation fails
+func (b *Token) RestoreBalance(accId string, balance *big.Int) error {
+	currentBalance, err := b.DB.BalanceOf(accId)
+	if err != nil {
+		return err
+	}
+	b.observeBalance(accId, currentBalance, balance)
+	return b.DB.UpdateBalance(accId, balance)
+}
+
+// capHolds shrinks the active holds of an account in hold id order, so they don't exceed its balance
+func (b *Token) capHolds(accId string, balance *big.Int) {
+	remaining := new(big.Int).Set(balance)
+	for _, hold := range b.DB.HoldsOf(accId) {
+		if b.holdExpired(hold) {
+			continue
+		}
+		switch {
+		case hold.Quantity.Cmp(remaining) <= 0:
+			remaining.Sub(remaining, hold.Quantity)
+		case remaining.Sign() == 0:
+			b.DB.DeleteHold(hold.Id)
+		default:
+			hold.Quantity = new(big.Int).Set(remaining)
+			b.DB.SetHold(hold)
+			remaining.SetInt64(0)
+		}
+	}
+}
+
 func (b *Token) Sub(accId string, amount *big.Int) error {
 	// Skip operation if amount is zero
 	if amount.Cmp(big.NewInt(0)) == 0 {
 		return nil
 	}
 
-	// Check sufficient balance
+	// Check sufficient balance, held amounts are not spendable
 	currentBalance, err := b.DB.BalanceOf(accId)
 	if err != nil {
 		return err
 	}
-	if currentBalance.Cmp(amount) < 0 {
+	if new(big.Int).Sub(currentBalance, b.HeldOf(accId)).Cmp(amount) < 0 {
 		return schema.ErrInsufficientBalance
 	}
 
+	// Accrue rewards on the old balance
+	if err = b.settleRewards(accId); err != nil {
+		return err
+	}
+
 	// Calculate new balance and update
-	return b.DB.UpdateBalance(accId, new(big.Int).Sub(currentBalance, amount))
+	newBalance := new(big.Int).Sub(currentBalance, amount)
+	b.observeBalance(accId, currentBalance, newBalance)
+	return b.DB.UpdateBalance(accId, newBalance)
 }
 
 func (b *Token) Add(accId string, amount *big.Int) error {
@@ -75,5 +159,12 @@ func (b *Token) Add(accId string, amount *big.Int) error {
 		return err
 	}
 
-	return b.DB.UpdateBalance(accId, new(big.Int).Add(currentBalance, amount))
+	// Accrue rewards on the old balance
+	if err = b.settleRewards(accId); err != nil {
+		return err
+	}
+
+	newBalance := new(big.Int).Add(currentBalance, amount)
+	b.observeBalance(accId, currentBalance, newBalance)
+	return b.DB.UpdateBalance(accId, newBalance)
 }
@@ -0,0 +1,138 @@
+package basic
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"slices"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// ParsePolicy strictly decodes and normalizes a policy document
+func ParsePolicy(policyStr string) (policy schema.Policy, err error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(policyStr)))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&policy); err != nil {
+		return schema.Policy{}, schema.ErrInvalidPolicy
+	}
+
+	for _, amount := range []string{policy.MaxTransferAmount, policy.MaxBalance} {
+		if amount == "" {
+			continue
+		}
+		if amt, ok := new(big.Int).SetString(amount, 10); !ok || amt.Sign() <= 0 {
+			return schema.Policy{}, schema.ErrInvalidPolicy
+		}
+	}
+
+	for i, accId := range policy.Allowlist {
+		_, accId, err = utils.IDCheck(accId)
+		if err != nil {
+			return schema.Policy{}, schema.ErrInvalidPolicy
+		}
+		policy.Allowlist[i] = accId
+	}
+
+	for _, window := range policy.TransferWindows {
+		if window.Start >= window.End {
+			return schema.Policy{}, schema.ErrInvalidPolicy
+		}
+	}
+	return
+}
+
+// checkTransferPolicy evaluates every policy rule for a transfer
+func (b *Token) checkTransferPolicy(to string, amount *big.Int) error {
+	policy := b.DB.Policy()
+	if policy.MaxTransferAmount != "" {
+		maxAmount, _ := new(big.Int).SetString(policy.MaxTransferAmount, 10)
+		if amount.Cmp(maxAmount) > 0 {
+			return schema.ErrPolicyMaxTransferAmount
+		}
+	}
+
+	if len(policy.TransferWindows) > 0 {
+		inWindow := slices.ContainsFunc(policy.TransferWindows, func(window schema.TransferWindow) bool {
+			return window.Start <= b.Now && b.Now < window.End
+		})
+		if !inWindow {
+			return schema.ErrPolicyOutsideTransferWindow
+		}
+	}
+
+	return b.checkRecipientPolicy(policy, to, amount)
+}
+
+// checkMintPolicy evaluates the recipient rules of the policy for a mint
+func (b *Token) checkMintPolicy(to string, amount *big.Int) error {
+	return b.checkRecipientPolicy(b.DB.Policy(), to, amount)
+}
+
+func (b *Token) checkRecipientPolicy(policy schema.Policy, to string, amount *big.Int) error {
+	if policy.AllowlistOnly && !slices.Contains(policy.Allowlist, to) {
+		return schema.ErrPolicyRecipientNotAllowed
+	}
+
+	if policy.MaxBalance != "" {
+		maxBalance, _ := new(big.Int).SetString(policy.MaxBalance, 10)
+		balance, err := b.DB.BalanceOf(to)
+		if err != nil {
+			return err
+		}
+		if new(big.Int).Add(balance, amount).Cmp(maxBalance) > 0 {
+			return schema.ErrPolicyMaxBalance
+		}
+	}
+	return nil
+}
+
+// HandleSetPolicy replaces the transfer policy (Owner only), the empty JSON object {} clears it.
+// A missing or empty Policy param is rejected.
+func (b *Token) HandleSetPolicy(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != b.DB.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	policyStr, exists := params["Policy"]
+	if !exists || policyStr == "" {
+		res.Error = schema.ErrInvalidPolicy
+		return
+	}
+	policy, err := ParsePolicy(policyStr)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	b.DB.SetPolicy(policy)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Set-Policy-Notice", Value: "success"},
+			},
+		},
+	}
+	res.Cache = b.CachePolicy()
+	return
+}
+
+func (b *Token) HandlePolicy(from string) (res vmmSchema.Result) {
+	policyBy, _ := json.Marshal(b.DB.Policy())
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   string(policyBy),
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Policy"},
+				{Name: "Ticker", Value: b.DB.Info().Ticker},
+			},
+		},
+	}
+	return
+}
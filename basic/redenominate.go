@@ -0,0 +1,222 @@
+package basic
+
+import (
+	"encoding/json"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// parseRedenominateMultisig validates the RedenominateSigners JSON array and RedenominateThreshold params,
+// an empty param keeps the given current value. Once configured, the multisig can't be disabled by the owner.
+func parseRedenominateMultisig(signersStr, thresholdStr string, signers []string, threshold int) ([]string, int, error) {
+	configured := threshold > 0
+	var err error
+	if signersStr != "" {
+		signers = nil
+		if err = json.Unmarshal([]byte(signersStr), &signers); err != nil {
+			return nil, 0, schema.ErrInvalidMultisig
+		}
+		for i, signer := range signers {
+			if _, signers[i], err = utils.IDCheck(signer); err != nil {
+				return nil, 0, schema.ErrInvalidMultisig
+			}
+		}
+	}
+	if thresholdStr != "" {
+		if threshold, err = strconv.Atoi(thresholdStr); err != nil {
+			return nil, 0, schema.ErrInvalidMultisig
+		}
+	}
+	if threshold < 0 || threshold > len(signers) || (configured && threshold == 0) {
+		return nil, 0, schema.ErrInvalidMultisig
+	}
+	return signers, threshold, nil
+}
+
+// HandleRedenominate multiplies every balance by Numerator/Denominator (Owner only).
+// With a redenomination multisig the operation waits for the signer approvals.
+func (b *Token) HandleRedenominate(itemId, from string, params map[string]string) (res vmmSchema.Result) {
+	if from != b.DB.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	numerator, ok := new(big.Int).SetString(params["Numerator"], 10)
+	if !ok || numerator.Sign() <= 0 {
+		res.Error = schema.ErrInvalidRatio
+		return
+	}
+	denominator, ok := new(big.Int).SetString(params["Denominator"], 10)
+	if !ok || denominator.Sign() <= 0 {
+		res.Error = schema.ErrInvalidRatio
+		return
+	}
+
+	decimals := params["Decimals"]
+	if decimals != "" {
+		if _, err := strconv.ParseUint(decimals, 10, 8); err != nil {
+			res.Error = schema.ErrInvalidDecimals
+			return
+		}
+	}
+
+	proposal := schema.Redenomination{
+		Id:          itemId,
+		Proposer:    from,
+		Numerator:   numerator,
+		Denominator: denominator,
+		Decimals:    decimals,
+		Reason:      params["Reason"],
+	}
+
+	signers, threshold := b.DB.RedenominateMultisig()
+	if threshold == 0 {
+		return b.redenominate(itemId, proposal)
+	}
+
+	// A new proposal replaces the pending one
+	b.DB.SetPendingRedenomination(&proposal)
+
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Redenominate-Proposal-Notice"},
+		{Name: "ProposalId", Value: itemId},
+		{Name: "Numerator", Value: numerator.String()},
+		{Name: "Denominator", Value: denominator.String()},
+		{Name: "Decimals", Value: decimals},
+		{Name: "Threshold", Value: strconv.Itoa(threshold)},
+		{Name: "Ticker", Value: b.DB.Info().Ticker},
+	}
+	res.Messages = []*vmmSchema.ResMessage{{Target: from, Tags: tags}}
+	for _, signer := range signers {
+		res.Messages = append(res.Messages, &vmmSchema.ResMessage{Target: signer, Tags: tags})
+	}
+	return
+}
+
+// HandleApproveRedenominate records a signer approval and executes the pending redenomination at the threshold
+func (b *Token) HandleApproveRedenominate(itemId, from string, params map[string]string) (res vmmSchema.Result) {
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+	signers, threshold := b.DB.RedenominateMultisig()
+	if !slices.Contains(signers, from) {
+		res.Error = schema.ErrIncorrectSigner
+		return
+	}
+
+	proposalId := params["ProposalId"]
+	if proposalId == "" {
+		res.Error = schema.ErrMissingProposalId
+		return
+	}
+	proposal, ok := b.DB.PendingRedenomination()
+	if !ok || proposal.Id != proposalId {
+		res.Error = schema.ErrProposalNotFound
+		return
+	}
+	if slices.Contains(proposal.Approvals, from) {
+		res.Error = schema.ErrProposalAlreadyApproved
+		return
+	}
+
+	// Only the approvals of the current signers count
+	proposal.Approvals = append(proposal.Approvals, from)
+	approvals := 0
+	for _, approver := range proposal.Approvals {
+		if slices.Contains(signers, approver) {
+			approvals++
+		}
+	}
+	if approvals >= threshold {
+		b.DB.SetPendingRedenomination(nil)
+		return b.redenominate(itemId, proposal)
+	}
+	b.DB.SetPendingRedenomination(&proposal)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Approve-Redenominate-Notice"},
+				{Name: "ProposalId", Value: proposal.Id},
+				{Name: "Approvals", Value: strconv.Itoa(approvals)},
+				{Name: "Threshold", Value: strconv.Itoa(threshold)},
+			},
+		},
+	}
+	return
+}
+
+func (b *Token) redenominate(itemId string, proposal schema.Redenomination) (res vmmSchema.Result) {
+	// Rewards accrue on the balances before the redenomination
+	if err := b.SettleAllRewards(); err != nil {
+		res.Error = err
+		return
+	}
+
+	oldBalances, err := b.DB.Balances()
+	if err != nil {
+		res.Error = err
+		return
+	}
+	oldTotalSupply := b.DB.GetTotalSupply()
+
+	// Nothing after the scaling can fail, the redenomination is applied as a whole
+	dust, balances := b.DB.Redenominate(proposal.Numerator, proposal.Denominator)
+	b.ObserveAllTwabs(oldBalances, balances, oldTotalSupply)
+
+	info := b.DB.Info()
+	oldDecimals := info.Decimals
+	if proposal.Decimals != "" {
+		info.Decimals = proposal.Decimals
+		b.DB.SetInfo(info)
+	}
+
+	ratio := proposal.Numerator.String() + "/" + proposal.Denominator.String()
+	b.DB.AppendAuditRecord(schema.AuditRecord{
+		Action:    "Redenominate",
+		Operator:  proposal.Proposer,
+		Quantity:  dust.String(),
+		Reason:    proposal.Reason,
+		ItemId:    itemId,
+		Timestamp: b.Now,
+		Ratio:     ratio,
+	})
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: proposal.Proposer,
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Redenominate-Notice"},
+				{Name: "ProposalId", Value: proposal.Id},
+				{Name: "Numerator", Value: proposal.Numerator.String()},
+				{Name: "Denominator", Value: proposal.Denominator.String()},
+				{Name: "OldTotalSupply", Value: oldTotalSupply.String()},
+				{Name: "TotalSupply", Value: b.DB.GetTotalSupply().String()},
+				{Name: "Dust", Value: dust.String()},
+				{Name: "OldDecimals", Value: oldDecimals},
+				{Name: "Decimals", Value: info.Decimals},
+				{Name: "Approvals", Value: strings.Join(proposal.Approvals, ",")},
+				{Name: "Ticker", Value: info.Ticker},
+			},
+		},
+	}
+
+	// Every balance has changed
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, b.CacheBalances())
+	maps.Copy(res.Cache, b.CacheTotalSupply())
+	maps.Copy(res.Cache, b.CachePolicy())
+	maps.Copy(res.Cache, b.cacheTokenInfo())
+	return
+}
@@ -0,0 +1,415 @@
+package basic
+
+import (
+	"encoding/json"
+	"math/big"
+	"slices"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// MaxRewardTokens caps the allowed reward tokens, every balance change settles each reward token
+const MaxRewardTokens = 10
+
+// rewardPool is the account holding the undistributed rewards paid in the token itself
+func (b *Token) rewardPool() string {
+	return b.DB.Info().Id
+}
+
+func (b *Token) rewardExcluded(accId string) bool {
+	return accId == b.rewardPool() || slices.Contains(b.DB.RewardExclusions(), accId)
+}
+
+// pendingRewards returns the rewards of an account that have not been settled yet
+func (b *Token) pendingRewards(accId, rewardToken string, balance *big.Int) schema.RewardAccount {
+	account := b.DB.RewardAccount(accId, rewardToken)
+	rewardPerToken := b.DB.RewardPerToken(rewardToken)
+	if !b.rewardExcluded(accId) {
+		delta := new(big.Int).Sub(rewardPerToken, account.Paid)
+		delta.Mul(delta, balance).Quo(delta, schema.RewardPrecision)
+		account.Owed.Add(account.Owed, delta)
+	}
+	account.Paid = rewardPerToken
+	return account
+}
+
+// settleRewards accrues the rewards of an account for every reward token,
+// it must run before every change of the account balance
+func (b *Token) settleRewards(accId string) error {
+	rewardTokens := b.DB.RewardTokens()
+	if len(rewardTokens) == 0 {
+		return nil
+	}
+	balance, err := b.DB.BalanceOf(accId)
+	if err != nil {
+		return err
+	}
+	_, accId, _ = utils.IDCheck(accId)
+	for _, rewardToken := range rewardTokens {
+		b.DB.SetRewardAccount(accId, rewardToken, b.pendingRewards(accId, rewardToken, balance))
+	}
+	return nil
+}
+
+// SettleAllRewards settles every holder, for operations changing all balances at once
+func (b *Token) SettleAllRewards() error {
+	if len(b.DB.RewardTokens()) == 0 {
+		return nil
+	}
+	balances, err := b.DB.Balances()
+	if err != nil {
+		return err
+	}
+	for accId := range balances {
+		if err = b.settleRewards(accId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eligibleSupply is the supply earning rewards, the total supply without the excluded accounts
+func (b *Token) eligibleSupply() (*big.Int, error) {
+	supply := b.DB.GetTotalSupply()
+	excluded := append(b.DB.RewardExclusions(), b.rewardPool())
+	slices.Sort(excluded)
+	for _, accId := range slices.Compact(excluded) {
+		balance, err := b.DB.BalanceOf(accId)
+		if err != nil {
+			return nil, err
+		}
+		supply.Sub(supply, balance)
+	}
+	return supply, nil
+}
+
+// distribute credits a reward pool to the eligible holders pro rata to their balances
+func (b *Token) distribute(rewardToken string, amount, eligibleSupply *big.Int) {
+	increment := new(big.Int).Mul(amount, schema.RewardPrecision)
+	increment.Quo(increment, eligibleSupply)
+	b.DB.SetRewardPerToken(rewardToken, new(big.Int).Add(b.DB.RewardPerToken(rewardToken), increment))
+}
+
+func distributeNotice(target, rewardToken, distributor string, amount, eligibleSupply *big.Int, ticker string) *vmmSchema.ResMessage {
+	return &vmmSchema.ResMessage{
+		Target: target,
+		Tags: []goarSchema.Tag{
+			{Name: "Action", Value: "Distribute-Notice"},
+			{Name: "RewardToken", Value: rewardToken},
+			{Name: "Distributor", Value: distributor},
+			{Name: "Quantity", Value: amount.String()},
+			{Name: "EligibleSupply", Value: eligibleSupply.String()},
+			{Name: "Ticker", Value: ticker},
+		},
+	}
+}
+
+// HandleDistribute distributes a reward pool of the token itself taken from the sender balance
+func (b *Token) HandleDistribute(from string, params map[string]string) (res vmmSchema.Result) {
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+	if b.DB.IsFrozen(from) {
+		res.Error = schema.ErrAccountFrozen
+		return
+	}
+
+	// Parse and validate quantity
+	quantity, exists := params["Quantity"]
+	if !exists {
+		res.Error = schema.ErrMissingQuantity
+		return
+	}
+	amount, ok := new(big.Int).SetString(quantity, 10)
+	if !ok {
+		res.Error = schema.ErrInvalidQuantityFormat
+		return
+	}
+	if amount.Sign() <= 0 {
+		res.Error = schema.ErrIncorrectQuantity
+		return
+	}
+
+	// The pool leaves the distributor balance before it is shared
+	eligibleSupply, err := b.eligibleSupply()
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if !b.rewardExcluded(from) {
+		eligibleSupply.Sub(eligibleSupply, amount)
+	}
+	if eligibleSupply.Sign() <= 0 {
+		res.Error = schema.ErrNoEligibleSupply
+		return
+	}
+
+	pool := b.rewardPool()
+	if err = b.Sub(from, amount); err != nil {
+		res.Error = err
+		return
+	}
+	if err = b.Add(pool, amount); err != nil {
+		res.Error = err
+		return
+	}
+	b.distribute(pool, amount, eligibleSupply)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		distributeNotice(from, pool, from, amount, eligibleSupply, b.DB.Info().Ticker),
+	}
+	res.Cache = b.CacheChangeBalance(from, pool)
+	return
+}
+
+// HandleDistributeCredit distributes a reward pool received from another token through
+// a Credit-Notice with X-Action Distribute, the crediting token is the reward token
+func (b *Token) HandleDistributeCredit(from string, params map[string]string) (res vmmSchema.Result) {
+	if params["X-Action"] != "Distribute" {
+		return
+	}
+	_, rewardToken, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidRewardToken
+		return
+	}
+	amount, ok := new(big.Int).SetString(params["Quantity"], 10)
+	if !ok || amount.Sign() <= 0 {
+		res.Error = schema.ErrIncorrectQuantity
+		return
+	}
+	sender := params["Sender"]
+
+	// Only allowed reward tokens are distributed, the pool of any other token is returned to the sender
+	if !slices.Contains(b.DB.AllowedRewardTokens(), rewardToken) {
+		res.Messages = []*vmmSchema.ResMessage{refundCredit(rewardToken, sender, amount, schema.ErrRewardTokenNotAllowed)}
+		return
+	}
+
+	// Without eligible holders the pool is returned to the sender
+	eligibleSupply, err := b.eligibleSupply()
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if eligibleSupply.Sign() <= 0 {
+		res.Messages = []*vmmSchema.ResMessage{refundCredit(rewardToken, sender, amount, schema.ErrNoEligibleSupply)}
+		return
+	}
+	b.distribute(rewardToken, amount, eligibleSupply)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		distributeNotice(sender, rewardToken, sender, amount, eligibleSupply, b.DB.Info().Ticker),
+	}
+	return
+}
+
+// refundCredit returns a credited reward pool to its sender
+func refundCredit(rewardToken, sender string, amount *big.Int, reason error) *vmmSchema.ResMessage {
+	return &vmmSchema.ResMessage{
+		Target: rewardToken,
+		Tags: []goarSchema.Tag{
+			{Name: "Action", Value: "Transfer"},
+			{Name: "Recipient", Value: sender},
+			{Name: "Quantity", Value: amount.String()},
+			{Name: "X-Reason", Value: reason.Error()},
+		},
+	}
+}
+
+// HandleClaimRewards pays out the accrued rewards of the sender for one reward token
+func (b *Token) HandleClaimRewards(itemId, from string, params map[string]string) (res vmmSchema.Result) {
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+	if b.DB.IsFrozen(from) {
+		res.Error = schema.ErrAccountFrozen
+		return
+	}
+
+	// Claim the token itself unless another reward token is given
+	pool := b.rewardPool()
+	rewardToken := pool
+	if params["RewardToken"] != "" {
+		_, rewardToken, err = utils.IDCheck(params["RewardToken"])
+		if err != nil {
+			res.Error = schema.ErrInvalidRewardToken
+			return
+		}
+	}
+
+	if err = b.settleRewards(from); err != nil {
+		res.Error = err
+		return
+	}
+	account := b.DB.RewardAccount(from, rewardToken)
+	if account.Owed.Sign() <= 0 {
+		res.Error = schema.ErrNoRewards
+		return
+	}
+	amount := account.Owed
+	b.DB.SetRewardAccount(from, rewardToken, schema.RewardAccount{Paid: account.Paid, Owed: big.NewInt(0)})
+
+	claimNotice := &vmmSchema.ResMessage{
+		Target: from,
+		Tags: []goarSchema.Tag{
+			{Name: "Action", Value: "Claim-Rewards-Notice"},
+			{Name: "RewardToken", Value: rewardToken},
+			{Name: "Quantity", Value: amount.String()},
+			{Name: "Ticker", Value: b.DB.Info().Ticker},
+			{Name: "TransactionId", Value: itemId},
+		},
+	}
+
+	if rewardToken != pool {
+		// External rewards are paid by the reward token process
+		res.Messages = []*vmmSchema.ResMessage{
+			claimNotice,
+			{
+				Target: rewardToken,
+				Tags: []goarSchema.Tag{
+					{Name: "Action", Value: "Transfer"},
+					{Name: "Recipient", Value: from},
+					{Name: "Quantity", Value: amount.String()},
+					{Name: "X-Action", Value: "Claim-Rewards"},
+				},
+			},
+		}
+		return
+	}
+
+	if err = b.Transfer(pool, from, amount); err != nil {
+		b.DB.SetRewardAccount(from, rewardToken, account)
+		res.Error = err
+		return
+	}
+	res.Messages = []*vmmSchema.ResMessage{claimNotice}
+	res.Cache = b.CacheChangeBalance(pool, from)
+	return
+}
+
+// HandleRewards returns the claimable rewards of an account by reward token as JSON
+func (b *Token) HandleRewards(from string, params map[string]string) (res vmmSchema.Result) {
+	// Determine account to query (default to sender if not specified)
+	accountId := from
+	if account := params["Account"]; account != "" {
+		accountId = account
+	}
+	_, accountId, err := utils.IDCheck(accountId)
+	if err != nil {
+		res.Error = schema.ErrInvalidAccount
+		return
+	}
+	balance, err := b.DB.BalanceOf(accountId)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	rewards := make(map[string]string)
+	for _, rewardToken := range b.DB.RewardTokens() {
+		rewards[rewardToken] = b.pendingRewards(accountId, rewardToken, balance).Owed.String()
+	}
+	rewardsBy, _ := json.Marshal(rewards)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   string(rewardsBy),
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Rewards"},
+				{Name: "Account", Value: accountId},
+				{Name: "Ticker", Value: b.DB.Info().Ticker},
+			},
+		},
+	}
+	return
+}
+
+// HandleSetRewardExclusions replaces the accounts that don't earn rewards (Owner only)
+func (b *Token) HandleSetRewardExclusions(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != b.DB.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	var exclusions []string
+	if err := json.Unmarshal([]byte(params["Accounts"]), &exclusions); err != nil {
+		res.Error = schema.ErrInvalidRewardExclusions
+		return
+	}
+	for i, accId := range exclusions {
+		_, accId, err := utils.IDCheck(accId)
+		if err != nil {
+			res.Error = schema.ErrInvalidRewardExclusions
+			return
+		}
+		exclusions[i] = accId
+	}
+
+	// Accounts changing status are settled with their previous status
+	for _, accId := range append(b.DB.RewardExclusions(), exclusions...) {
+		if err := b.settleRewards(accId); err != nil {
+			res.Error = err
+			return
+		}
+	}
+	b.DB.SetRewardExclusions(exclusions)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Set-Reward-Exclusions-Notice", Value: "success"},
+			},
+		},
+	}
+	return
+}
+
+// HandleSetRewardTokens replaces the other tokens that may be distributed through a Credit-Notice,
+// at most MaxRewardTokens (Owner only)
+func (b *Token) HandleSetRewardTokens(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != b.DB.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	var rewardTokens []string
+	if err := json.Unmarshal([]byte(params["RewardTokens"]), &rewardTokens); err != nil {
+		res.Error = schema.ErrInvalidRewardTokens
+		return
+	}
+	if len(rewardTokens) > MaxRewardTokens {
+		res.Error = schema.ErrInvalidRewardTokens
+		return
+	}
+	for i, rewardToken := range rewardTokens {
+		_, rewardToken, err := utils.IDCheck(rewardToken)
+		if err != nil || rewardToken == b.rewardPool() || slices.Contains(rewardTokens[:i], rewardToken) {
+			res.Error = schema.ErrInvalidRewardTokens
+			return
+		}
+		rewardTokens[i] = rewardToken
+	}
+	b.DB.SetAllowedRewardTokens(rewardTokens)
+
+	rewardTokensBy, _ := json.Marshal(rewardTokens)
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Set-Reward-Tokens-Notice", Value: "success"},
+				{Name: "RewardTokens", Value: string(rewardTokensBy)},
+			},
+		},
+	}
+	return
+}
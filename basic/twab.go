@@ -0,0 +1,226 @@
+package basic
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// TwabMaxObservations bounds the history kept per account, older observations are dropped
+const TwabMaxObservations = 256
+
+// observeTwab records a balance change at the current message timestamp
+func observeTwab(twab schema.Twab, exists bool, oldBalance, newBalance *big.Int, now int64) schema.Twab {
+	if !exists {
+		// A balance held before the tracking started has no known history
+		if oldBalance.Sign() != 0 {
+			twab.Since = now
+		}
+		twab.Observations = append(twab.Observations, schema.TwabObservation{
+			Timestamp:  now,
+			Cumulative: big.NewInt(0),
+			Balance:    new(big.Int).Set(newBalance),
+		})
+		return twab
+	}
+
+	last := twab.Observations[len(twab.Observations)-1]
+	if last.Balance.Cmp(newBalance) == 0 {
+		return twab
+	}
+	if last.Timestamp >= now {
+		// Several changes in the same second, only the final balance counts
+		twab.Observations[len(twab.Observations)-1].Balance = new(big.Int).Set(newBalance)
+		return twab
+	}
+
+	cumulative := new(big.Int).Mul(last.Balance, big.NewInt(now-last.Timestamp))
+	twab.Observations = append(twab.Observations, schema.TwabObservation{
+		Timestamp:  now,
+		Cumulative: cumulative.Add(cumulative, last.Cumulative),
+		Balance:    new(big.Int).Set(newBalance),
+	})
+	if len(twab.Observations) > TwabMaxObservations {
+		twab.Observations = twab.Observations[len(twab.Observations)-TwabMaxObservations:]
+		twab.Since = twab.Observations[0].Timestamp
+	}
+	return twab
+}
+
+// twabCumulative returns the balance-seconds accumulated up to a timestamp (unix seconds)
+func twabCumulative(twab schema.Twab, timestamp int64) (*big.Int, error) {
+	if timestamp < twab.Since {
+		return nil, schema.ErrTwabOutOfRange
+	}
+	i := sort.Search(len(twab.Observations), func(i int) bool {
+		return twab.Observations[i].Timestamp > timestamp
+	})
+	if i == 0 {
+		// Nothing was held before the first observation
+		return big.NewInt(0), nil
+	}
+	observation := twab.Observations[i-1]
+	cumulative := new(big.Int).Mul(observation.Balance, big.NewInt(timestamp-observation.Timestamp))
+	return cumulative.Add(cumulative, observation.Cumulative), nil
+}
+
+func (b *Token) nowSeconds() int64 {
+	return b.Now / 1000
+}
+
+func (b *Token) observeBalance(accId string, oldBalance, newBalance *big.Int) {
+	_, accId, err := utils.IDCheck(accId)
+	if err != nil {
+		return
+	}
+	twab, exists := b.DB.Twab(accId)
+	b.DB.SetTwab(accId, observeTwab(twab, exists, oldBalance, newBalance, b.nowSeconds()))
+}
+
+// SetTotalSupply updates the total supply and its time-weighted history
+func (b *Token) SetTotalSupply(totalSupply *big.Int) {
+	oldTotalSupply := b.DB.GetTotalSupply()
+	b.DB.SetTotalSupply(totalSupply)
+
+	// Observe the stored value, a rebasing DB derives the total supply from the shares
+	twab, exists := b.DB.TotalSupplyTwab()
+	b.DB.SetTotalSupplyTwab(observeTwab(twab, exists, oldTotalSupply, b.DB.GetTotalSupply(), b.nowSeconds()))
+}
+
+// ObserveAllTwabs records the balances and the total supply after an operation changing all balances at once,
+// given the balances and the total supply before it and the balances after it
+func (b *Token) ObserveAllTwabs(oldBalances, balances map[string]*big.Int, oldTotalSupply *big.Int) {
+	for accId, oldBalance := range oldBalances {
+		newBalance, exists := balances[accId]
+		if !exists {
+			newBalance = big.NewInt(0)
+		}
+		b.observeBalance(accId, oldBalance, newBalance)
+	}
+	for accId, newBalance := range balances {
+		if _, exists := oldBalances[accId]; !exists {
+			b.observeBalance(accId, big.NewInt(0), newBalance)
+		}
+	}
+
+	twab, exists := b.DB.TotalSupplyTwab()
+	b.DB.SetTotalSupplyTwab(observeTwab(twab, exists, oldTotalSupply, b.DB.GetTotalSupply(), b.nowSeconds()))
+}
+
+// parseTwabRange parses the Start and End (UnixMilli, End defaults to now) params into unix seconds
+func (b *Token) parseTwabRange(params map[string]string) (start, end int64, err error) {
+	start, err = strconv.ParseInt(params["Start"], 10, 64)
+	if err != nil {
+		return 0, 0, schema.ErrInvalidTwabRange
+	}
+	end = b.Now
+	if params["End"] != "" {
+		end, err = strconv.ParseInt(params["End"], 10, 64)
+		if err != nil {
+			return 0, 0, schema.ErrInvalidTwabRange
+		}
+	}
+	if end > b.Now {
+		return 0, 0, schema.ErrInvalidTwabRange
+	}
+	start, end = start/1000, end/1000
+	if start < 0 || start >= end {
+		return 0, 0, schema.ErrInvalidTwabRange
+	}
+	return start, end, nil
+}
+
+// twabAverage returns the balance-seconds and the average balance over [start, end)
+func twabAverage(twab schema.Twab, start, end int64) (cumulative, average *big.Int, err error) {
+	startCumulative, err := twabCumulative(twab, start)
+	if err != nil {
+		return
+	}
+	endCumulative, err := twabCumulative(twab, end)
+	if err != nil {
+		return
+	}
+	cumulative = new(big.Int).Sub(endCumulative, startCumulative)
+	average = new(big.Int).Quo(cumulative, big.NewInt(end-start))
+	return
+}
+
+func (b *Token) twabResult(from, accountId string, twab schema.Twab, start, end int64) (res vmmSchema.Result) {
+	cumulative, average, err := twabAverage(twab, start, end)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "TWAB"},
+		{Name: "Start", Value: strconv.FormatInt(start*1000, 10)},
+		{Name: "End", Value: strconv.FormatInt(end*1000, 10)},
+		{Name: "Cumulative", Value: cumulative.String()},
+		{Name: "TWAB", Value: average.String()},
+		{Name: "Ticker", Value: b.DB.Info().Ticker},
+	}
+	if accountId != "" {
+		tags = append(tags, goarSchema.Tag{Name: "Account", Value: accountId})
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   average.String(),
+			Tags:   tags,
+		},
+	}
+	return
+}
+
+// HandleTwab returns the time-weighted average balance of an account over [Start, End)
+func (b *Token) HandleTwab(from string, params map[string]string) (res vmmSchema.Result) {
+	// Determine account to query (default to sender if not specified)
+	accountId := from
+	if account := params["Account"]; account != "" {
+		accountId = account
+	}
+	_, accountId, err := utils.IDCheck(accountId)
+	if err != nil {
+		res.Error = schema.ErrInvalidAccount
+		return
+	}
+	start, end, err := b.parseTwabRange(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	twab, exists := b.DB.Twab(accountId)
+	if !exists {
+		// The balance never changed since the tracking started
+		balance, err := b.DB.BalanceOf(accountId)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		twab = observeTwab(twab, false, balance, balance, b.nowSeconds())
+	}
+	return b.twabResult(from, accountId, twab, start, end)
+}
+
+// HandleTotalSupplyTwab returns the time-weighted average total supply over [Start, End)
+func (b *Token) HandleTotalSupplyTwab(from string, params map[string]string) (res vmmSchema.Result) {
+	start, end, err := b.parseTwabRange(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	twab, exists := b.DB.TotalSupplyTwab()
+	if !exists {
+		totalSupply := b.DB.GetTotalSupply()
+		twab = observeTwab(twab, false, totalSupply, totalSupply, b.nowSeconds())
+	}
+	return b.twabResult(from, "", twab, start, end)
+}
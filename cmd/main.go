@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/aox-labs/hymx-vmtoken/basic"
 	"github.com/aox-labs/hymx-vmtoken/crosschain"
+	"github.com/aox-labs/hymx-vmtoken/rebase"
 	"github.com/aox-labs/hymx-vmtoken/schema"
 	"github.com/gin-gonic/gin"
 	"github.com/hymatrix/hymx/common"
@@ -72,6 +73,7 @@ func run(c *cli.Context) (err error) {
 	// mount vm token variants
 	s.Mount(schema.VmTokenBasicModuleFormat, basic.Spawn)
 	s.Mount(schema.VmTokenCrossChainMultiModuleFormat, crosschain.Spawn)
+	s.Mount(schema.VmTokenRebaseModuleFormat, rebase.Spawn)
 
 	s.Run(port)
 
@@ -0,0 +1,232 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// parseRelayerSet validates the Relayers JSON array and RelayerThreshold params,
+// an empty param keeps the given current value
+func parseRelayerSet(relayersStr, thresholdStr string, relayers []string, threshold int) ([]string, int, error) {
+	var err error
+	if relayersStr != "" {
+		relayers = nil
+		if err = json.Unmarshal([]byte(relayersStr), &relayers); err != nil {
+			return nil, 0, schema.ErrInvalidRelayers
+		}
+		for i, relayer := range relayers {
+			if _, relayers[i], err = utils.IDCheck(relayer); err != nil {
+				return nil, 0, schema.ErrInvalidRelayers
+			}
+			if slices.Contains(relayers[:i], relayers[i]) {
+				return nil, 0, schema.ErrInvalidRelayers
+			}
+		}
+	}
+	if thresholdStr != "" {
+		if threshold, err = strconv.Atoi(thresholdStr); err != nil {
+			return nil, 0, schema.ErrInvalidRelayers
+		}
+	}
+	if threshold < 0 || threshold > len(relayers) {
+		return nil, 0, schema.ErrInvalidRelayers
+	}
+	return relayers, threshold, nil
+}
+
+// parseMintClaim validates and normalizes the mint attested by a relayer
+func parseMintClaim(params map[string]string) (claim schema.MintAttestation, err error) {
+	claim.MintTxHash = params["X-MintTxHash"]
+	if claim.MintTxHash == "" {
+		return claim, schema.ErrMissingMintTxHash
+	}
+	if params["Recipient"] == "" {
+		return claim, schema.ErrMissingRecipient
+	}
+	if _, claim.Recipient, err = utils.IDCheck(params["Recipient"]); err != nil {
+		return claim, schema.ErrInvalidRecipient
+	}
+	if params["Quantity"] == "" {
+		return claim, schema.ErrMissingQuantity
+	}
+	quantity, ok := new(big.Int).SetString(params["Quantity"], 10)
+	if !ok {
+		return claim, schema.ErrInvalidQuantityFormat
+	}
+	claim.Quantity = quantity.String()
+	if claim.SourceChainType = params["SourceChainType"]; claim.SourceChainType == "" {
+		return claim, schema.ErrMissingSourceChain
+	}
+	if params["SourceTokenId"] == "" {
+		return claim, schema.ErrMissingSourceTokenId
+	}
+	if _, claim.SourceTokenId, err = utils.IDCheck(params["SourceTokenId"]); err != nil {
+		return claim, schema.ErrInvalidSourceTokenId
+	}
+	if claim.MintTxHash, err = parseMintTxHash(claim.SourceChainType, claim.MintTxHash); err != nil {
+		return claim, err
+	}
+	claim.BlockHeight = params["X-BlockHeight"]
+	return claim, nil
+}
+
+// sameMint reports whether two attestations describe the same mint
+func sameMint(a, b schema.MintAttestation) bool {
+	return a.MintTxHash == b.MintTxHash &&
+		a.Recipient == b.Recipient &&
+		a.Quantity == b.Quantity &&
+		a.SourceChainType == b.SourceChainType &&
+		a.SourceTokenId == b.SourceTokenId &&
+		a.BlockHeight == b.BlockHeight
+}
+
+// attestationAlert notifies the owner and the relayers of a conflicting attestation
+func (t *Token) attestationAlert(relayer string, attestation, claim schema.MintAttestation, relayers []string) []*vmmSchema.ResMessage {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Mint-Attestation-Alert"},
+		{Name: "X-MintTxHash", Value: attestation.MintTxHash},
+		{Name: "Relayer", Value: relayer},
+		{Name: "Recipient", Value: claim.Recipient},
+		{Name: "Quantity", Value: claim.Quantity},
+		{Name: "SourceChainType", Value: claim.SourceChainType},
+		{Name: "SourceTokenId", Value: claim.SourceTokenId},
+		{Name: "AttestedRecipient", Value: attestation.Recipient},
+		{Name: "AttestedQuantity", Value: attestation.Quantity},
+		{Name: "AttestedSourceChainType", Value: attestation.SourceChainType},
+		{Name: "AttestedSourceTokenId", Value: attestation.SourceTokenId},
+		{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+	}
+	messages := []*vmmSchema.ResMessage{{Target: t.basic.DB.Owner(), Tags: tags}}
+	for _, target := range relayers {
+		messages = append(messages, &vmmSchema.ResMessage{Target: target, Tags: tags})
+	}
+	return messages
+}
+
+// handleAttestMint records a relayer attestation of a mint, the mint executes once the threshold
+// of matching attestations is reached. A conflicting attestation freezes the mint.
+func (t *Token) handleAttestMint(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+	relayers, threshold := t.db.RelayerSet()
+	if threshold == 0 || !slices.Contains(relayers, from) {
+		res.Error = schema.ErrIncorrectRelayer
+		return
+	}
+
+	claim, err := parseMintClaim(meta.Params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if t.minted(claim.MintTxHash) {
+		res.Error = schema.ErrRepeatMint
+		return
+	}
+
+	attestation, exists := t.db.MintAttestation(claim.MintTxHash)
+	if !exists {
+		attestation = claim
+	}
+	if attestation.Frozen {
+		res.Error = schema.ErrMintAttestationFrozen
+		return
+	}
+	if !sameMint(attestation, claim) {
+		attestation.Frozen = true
+		attestation.Conflicts = append(attestation.Conflicts, from)
+		t.db.SetMintAttestation(attestation)
+		res.Messages = t.attestationAlert(from, attestation, claim, relayers)
+		return
+	}
+	if slices.Contains(attestation.Attesters, from) {
+		res.Error = schema.ErrAlreadyAttested
+		return
+	}
+
+	// Only the attestations of the current relayers count
+	attestation.Attesters = append(attestation.Attesters, from)
+	attestations := 0
+	for _, attester := range attestation.Attesters {
+		if slices.Contains(relayers, attester) {
+			attestations++
+		}
+	}
+	if attestations < threshold {
+		t.db.SetMintAttestation(attestation)
+		res.Messages = []*vmmSchema.ResMessage{
+			{
+				Target: from,
+				Tags: []goarSchema.Tag{
+					{Name: "Action", Value: "Attest-Mint-Notice"},
+					{Name: "X-MintTxHash", Value: attestation.MintTxHash},
+					{Name: "Attestations", Value: strconv.Itoa(attestations)},
+					{Name: "Threshold", Value: strconv.Itoa(threshold)},
+				},
+			},
+		}
+		return
+	}
+
+	// A failed mint leaves this attestation unrecorded so the relayer can retry
+	mintMeta := meta
+	mintMeta.Params = map[string]string{
+		"Recipient":       attestation.Recipient,
+		"Quantity":        attestation.Quantity,
+		"SourceChainType": attestation.SourceChainType,
+		"SourceTokenId":   attestation.SourceTokenId,
+		"X-MintTxHash":    attestation.MintTxHash,
+		"X-BlockHeight":   attestation.BlockHeight,
+	}
+	res = t.crossChainMint(from, mintMeta)
+	if res.Error != nil {
+		return
+	}
+	t.db.DeleteMintAttestation(attestation.MintTxHash)
+	return
+}
+
+// handleClearMintAttestation drops the attestations of a mint, e.g. to unfreeze it (Owner only)
+func (t *Token) handleClearMintAttestation(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != t.basic.DB.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	mintTxHash := canonicalMintTxHash(params["SourceChainType"], params["X-MintTxHash"])
+	if _, exists := t.db.MintAttestation(mintTxHash); !exists {
+		res.Error = schema.ErrAttestationNotFound
+		return
+	}
+	t.db.DeleteMintAttestation(mintTxHash)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Clear-Mint-Attestation-Notice", Value: "success"},
+				{Name: "X-MintTxHash", Value: mintTxHash},
+			},
+		},
+	}
+	return
+}
+
+// handleMintAttestation returns the pending attestations of a mint as JSON
+func (t *Token) handleMintAttestation(from string, params map[string]string) (res vmmSchema.Result) {
+	attestation, exists := t.db.MintAttestation(canonicalMintTxHash(params["SourceChainType"], params["X-MintTxHash"]))
+	if !exists {
+		res.Error = schema.ErrAttestationNotFound
+		return
+	}
+	return t.recordsResult(from, "Mint-Attestation", attestation, "", nil)
+}
@@ -0,0 +1,242 @@
+package crosschain
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+const (
+	// DayMillis is the rolling window of the daily caps
+	DayMillis = 24 * 60 * 60 * 1000
+	// HourMillis is the size of the buckets the flows are counted in
+	HourMillis = 60 * 60 * 1000
+)
+
+// bridgeUsage is the amount minted and burned under a bridge limit key over the rolling day
+type bridgeUsage struct {
+	Inflow  string
+	Outflow string
+}
+
+// parseBridgeLimits strictly decodes the bridge limits keyed by chainType or chainType:tokenId,
+// a null limit removes the key
+func parseBridgeLimits(limitsStr string) (map[string]*schema.BridgeLimit, error) {
+	limits := make(map[string]*schema.BridgeLimit)
+	decoder := json.NewDecoder(bytes.NewReader([]byte(limitsStr)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&limits); err != nil || len(limits) == 0 {
+		return nil, schema.ErrInvalidBridgeLimit
+	}
+	for key, limit := range limits {
+		if key == "" {
+			return nil, schema.ErrInvalidBridgeLimit
+		}
+		if limit == nil {
+			continue
+		}
+		for _, amount := range []string{limit.MaxPerTx, limit.DailyInflowCap, limit.DailyOutflowCap} {
+			if _, ok := parseFeeAmount(amount); !ok {
+				return nil, schema.ErrInvalidBridgeLimit
+			}
+		}
+	}
+	return limits, nil
+}
+
+// bridgeLimitKeys returns the keys of the bridge limits applying to a source token
+func bridgeLimitKeys(sourceToken schema.SourceToken) []string {
+	return []string{sourceToken.ChainType, sourceToken.ChainType + ":" + sourceToken.TokenId}
+}
+
+// recentFlows drops the buckets ended before the rolling day and returns the total of the others.
+// A bucket counts until its whole hour has left the day, so the caps never allow more than their amount.
+func recentFlows(entries []schema.FlowEntry, now int64) ([]schema.FlowEntry, *big.Int) {
+	recent := make([]schema.FlowEntry, 0, len(entries))
+	total := big.NewInt(0)
+	for _, entry := range entries {
+		if entry.Timestamp+HourMillis <= now-DayMillis {
+			continue
+		}
+		amount, _ := new(big.Int).SetString(entry.Amount, 10)
+		total.Add(total, amount)
+		recent = append(recent, entry)
+	}
+	return recent, total
+}
+
+// checkBridgeLimit checks a mint or a burn of an amount in wrapped units against the bridge limit of a key
+func (t *Token) checkBridgeLimit(key string, amount *big.Int, mint bool) error {
+	limit, ok := t.db.BridgeLimit(key)
+	if !ok {
+		return nil
+	}
+	if mint && limit.MintPaused {
+		return schema.ErrMintPaused
+	}
+	if !mint && limit.BurnPaused {
+		return schema.ErrBurnPaused
+	}
+	flow := t.db.BridgeFlow(key)
+	capStr, entries := limit.DailyOutflowCap, flow.Outflows
+	if mint {
+		capStr, entries = limit.DailyInflowCap, flow.Inflows
+	}
+	if limit.MaxPerTx != "" {
+		if maxPerTx, _ := parseFeeAmount(limit.MaxPerTx); amount.Cmp(maxPerTx) > 0 {
+			return schema.ErrExceedsMaxPerTx
+		}
+	}
+	if capStr != "" {
+		dailyCap, _ := parseFeeAmount(capStr)
+		_, total := recentFlows(entries, t.basic.Now)
+		if total.Add(total, amount).Cmp(dailyCap) > 0 {
+			return schema.ErrDailyCapExceeded
+		}
+	}
+	return nil
+}
+
+// addToBucket adds an amount to the bucket starting at start, a bucket reaching zero is dropped
+func addToBucket(entries []schema.FlowEntry, start int64, amount *big.Int) []schema.FlowEntry {
+	for i, entry := range entries {
+		if entry.Timestamp != start {
+			continue
+		}
+		total, _ := new(big.Int).SetString(entry.Amount, 10)
+		if total.Add(total, amount).Sign() <= 0 {
+			return append(entries[:i], entries[i+1:]...)
+		}
+		entries[i].Amount = total.String()
+		return entries
+	}
+	if amount.Sign() > 0 {
+		entries = append(entries, schema.FlowEntry{Amount: amount.String(), Timestamp: start})
+	}
+	return entries
+}
+
+// recordFlow adds a mint or a burn made at timestamp to the hourly buckets of a key, a negative amount
+// takes it back. Only the keys with a daily cap in that direction are recorded.
+func (t *Token) recordFlow(key string, amount *big.Int, mint bool, timestamp int64) {
+	limit, ok := t.db.BridgeLimit(key)
+	if !ok || (mint && limit.DailyInflowCap == "") || (!mint && limit.DailyOutflowCap == "") {
+		return
+	}
+	flow := t.db.BridgeFlow(key)
+	flow.Inflows, _ = recentFlows(flow.Inflows, t.basic.Now)
+	flow.Outflows, _ = recentFlows(flow.Outflows, t.basic.Now)
+	start := timestamp - timestamp%HourMillis
+	if mint {
+		flow.Inflows = addToBucket(flow.Inflows, start, amount)
+	} else {
+		flow.Outflows = addToBucket(flow.Outflows, start, amount)
+	}
+	t.db.SetBridgeFlow(key, flow)
+}
+
+// checkMintLimits checks a mint of a source token in wrapped units against its bridge limits
+func (t *Token) checkMintLimits(sourceToken schema.SourceToken, amount *big.Int) error {
+	for _, key := range bridgeLimitKeys(sourceToken) {
+		if err := t.checkBridgeLimit(key, amount, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordMint adds a mint to the rolling day of its bridge limit keys
+func (t *Token) recordMint(sourceToken schema.SourceToken, amount *big.Int) {
+	for _, key := range bridgeLimitKeys(sourceToken) {
+		t.recordFlow(key, amount, true, t.basic.Now)
+	}
+}
+
+// checkBurnLimits checks the legs of a burn against the bridge limits of their source tokens,
+// the limits of the chain type apply to the whole burn
+func (t *Token) checkBurnLimits(quotes []burnQuote) error {
+	total := big.NewInt(0)
+	for _, quote := range quotes {
+		if err := t.checkBridgeLimit(bridgeLimitKeys(quote.targetToken)[1], quote.amount, false); err != nil {
+			return err
+		}
+		total.Add(total, quote.amount)
+	}
+	return t.checkBridgeLimit(quotes[0].targetToken.ChainType, total, false)
+}
+
+// recordBurn adds the legs of a burn to the rolling day of their bridge limit keys
+func (t *Token) recordBurn(quotes []burnQuote) {
+	for _, quote := range quotes {
+		for _, key := range bridgeLimitKeys(quote.targetToken) {
+			t.recordFlow(key, quote.amount, false, t.basic.Now)
+		}
+	}
+}
+
+// unrecordBurn takes a refunded burn leg back from the hour it was recorded in, nothing left the bridge
+func (t *Token) unrecordBurn(record schema.BurnRecord) {
+	amount, _ := new(big.Int).SetString(record.Quantity, 10)
+	for _, field := range []string{record.Fee, record.Dust} {
+		if value, ok := new(big.Int).SetString(field, 10); ok {
+			amount.Add(amount, value)
+		}
+	}
+	targetToken := schema.SourceToken{TokenId: record.TargetTokenId, ChainType: record.TargetChainType}
+	for _, key := range bridgeLimitKeys(targetToken) {
+		t.recordFlow(key, amount.Neg(amount), false, record.Timestamp)
+		amount.Neg(amount)
+	}
+}
+
+// bridgeUsages returns the amounts minted and burned over the rolling day per bridge limit key
+func (t *Token) bridgeUsages() map[string]bridgeUsage {
+	usages := make(map[string]bridgeUsage)
+	for key, flow := range t.db.BridgeFlows() {
+		_, inflow := recentFlows(flow.Inflows, t.basic.Now)
+		_, outflow := recentFlows(flow.Outflows, t.basic.Now)
+		if inflow.Sign() == 0 && outflow.Sign() == 0 {
+			continue
+		}
+		usages[key] = bridgeUsage{Inflow: inflow.String(), Outflow: outflow.String()}
+	}
+	return usages
+}
+
+// handleSetBridgeLimits merges the BridgeLimits param into the bridge limits (Owner or BridgeAdmin only)
+func (t *Token) handleSetBridgeLimits(from string, params map[string]string) (res vmmSchema.Result) {
+	if !t.isBridgeAdmin(from) {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	limits, err := parseBridgeLimits(params["BridgeLimits"])
+	if err != nil {
+		res.Error = err
+		return
+	}
+	for key, limit := range limits {
+		t.db.SetBridgeLimit(key, limit)
+		if limit == nil {
+			t.db.SetBridgeFlow(key, schema.BridgeFlow{})
+		}
+	}
+
+	limitsBy, _ := json.Marshal(t.db.BridgeLimits())
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Set-Bridge-Limits-Notice", Value: "success"},
+				{Name: "BridgeLimits", Value: string(limitsBy)},
+			},
+		},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	return
+}
@@ -0,0 +1,172 @@
+package crosschain
+
+import (
+	"maps"
+	"math/big"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// pendingBurn returns the pending burn of the BurnTxHash param
+func (t *Token) pendingBurn(params map[string]string) (schema.BurnRecord, error) {
+	record, ok := t.db.GetBurnRecord(params["BurnTxHash"])
+	if !ok {
+		return record, schema.ErrBurnRecordNotFound
+	}
+	if record.Status != schema.BurnStatusPending {
+		return record, schema.ErrBurnNotPending
+	}
+	return record, nil
+}
+
+func (t *Token) burnNotice(target, action string, record schema.BurnRecord) *vmmSchema.ResMessage {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: action},
+		{Name: "BurnTxHash", Value: record.BurnTxHash},
+		{Name: "Sender", Value: record.Sender},
+		{Name: "X-Recipient", Value: record.Recipient},
+		{Name: "Quantity", Value: record.Quantity},
+		{Name: "Fee", Value: record.Fee},
+		{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+		{Name: "TargetChainType", Value: record.TargetChainType},
+		{Name: "TargetTokenId", Value: record.TargetTokenId},
+	}
+	if record.TargetTxHash != "" {
+		tags = append(tags, goarSchema.Tag{Name: "TargetTxHash", Value: record.TargetTxHash})
+	}
+	if record.Status == schema.BurnStatusRefunded {
+		tags = append(tags,
+			goarSchema.Tag{Name: "Reason", Value: record.RefundReason},
+			goarSchema.Tag{Name: "FeeRefunded", Value: strconv.FormatBool(record.FeeRefunded)},
+		)
+	}
+	return &vmmSchema.ResMessage{
+		Target: target,
+		Tags:   tags,
+	}
+}
+
+// handleBurnComplete marks a pending burn as released on the target chain (BurnProcessor only)
+func (t *Token) handleBurnComplete(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != t.db.GetBurnProcessor() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	record, err := t.pendingBurn(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if params["TargetTxHash"] == "" {
+		res.Error = schema.ErrMissingTargetTxHash
+		return
+	}
+	targetTxHash, ok := chainValidator(record.TargetChainType).TxHash(params["TargetTxHash"])
+	if !ok {
+		res.Error = schema.ErrInvalidTargetTxHash
+		return
+	}
+
+	record.Status = schema.BurnStatusCompleted
+	record.TargetTxHash = targetTxHash
+	record.ResolvedAt = t.basic.Now
+	t.db.AddBurnRecord(record)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		t.burnNotice(from, "Burn-Complete-Notice", record),
+		t.burnNotice(record.Sender, "Burn-Complete-Notice", record),
+	}
+	res.Cache = t.cacheBurnRecords()
+	return
+}
+
+// handleBurnRefund reverts a pending burn that can't be released on the target chain (BurnProcessor only).
+// The sender gets back the net amount, and the fee and the dust too with RefundFee, taken from the fee ledger,
+// or from the fee recipient of a burn made before the fee ledger.
+func (t *Token) handleBurnRefund(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != t.db.GetBurnProcessor() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	record, err := t.pendingBurn(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	amount, _ := new(big.Int).SetString(record.Quantity, 10)
+	sourceAmount, ok := new(big.Int).SetString(record.SourceQuantity, 10)
+	if !ok {
+		sourceAmount = new(big.Int).Set(amount)
+	}
+	fee, _ := new(big.Int).SetString(record.Fee, 10)
+	if dust, ok := new(big.Int).SetString(record.Dust, 10); ok {
+		fee.Add(fee, dust)
+	}
+
+	// The fee and the dust are taken back first, it is the only step that can fail
+	refund := new(big.Int).Set(amount)
+	supply := new(big.Int).Set(amount)
+	if params["RefundFee"] == "true" {
+		if record.FeeRecipient != "" {
+			err = t.basic.Sub(record.FeeRecipient, fee)
+		} else {
+			err = t.refundFee(record.TargetChainType, fee)
+			supply.Add(supply, fee)
+		}
+		if err != nil {
+			res.Error = err
+			return
+		}
+		refund.Add(refund, fee)
+		record.FeeRefunded = true
+	}
+
+	// Refunds are credited even to frozen accounts, the tokens were theirs
+	if err = t.basic.Add(record.Sender, refund); err != nil {
+		res.Error = err
+		return
+	}
+	t.basic.SetTotalSupply(new(big.Int).Add(t.basic.DB.GetTotalSupply(), supply))
+	lockAmt, _ := t.db.GetSourceLockAmount(record.TargetTokenId, record.TargetChainType)
+	t.db.SetSourceLockAmount(record.TargetTokenId, record.TargetChainType, new(big.Int).Add(lockAmt, sourceAmount))
+	t.unrecordBurn(record)
+
+	record.Status = schema.BurnStatusRefunded
+	record.RefundReason = params["Reason"]
+	record.ResolvedAt = t.basic.Now
+	t.db.AddBurnRecord(record)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		t.burnNotice(from, "Burn-Refund-Notice", record),
+		t.burnNotice(record.Sender, "Burn-Refund-Notice", record),
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	maps.Copy(res.Cache, t.basic.CacheChangeBalance(record.Sender, record.FeeRecipient))
+	maps.Copy(res.Cache, t.basic.CacheTotalSupply())
+	maps.Copy(res.Cache, t.cacheBurnRecords())
+	maps.Copy(res.Cache, t.cacheFees())
+	return
+}
+
+// handlePendingBurns lists the pending burns in burn order, optionally filtered by ChainType,
+// for the BurnProcessor to recover the burns it has not resolved
+func (t *Token) handlePendingBurns(from string, params map[string]string) (res vmmSchema.Result) {
+	pending := make([]schema.BurnRecord, 0)
+	for _, record := range t.db.BurnRecords(params["ChainType"]) {
+		if record.Status == schema.BurnStatusPending {
+			pending = append(pending, record)
+		}
+	}
+	page, next, err := paginate(pending, func(record schema.BurnRecord) string {
+		return record.BurnTxHash
+	}, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	return t.recordsResult(from, "Pending-Burns", page, next, params)
+}
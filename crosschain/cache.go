@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/aox-labs/hymx-vmtoken/schema"
 	"maps"
+	"strconv"
 )
 
 func (t *Token) initCache() (cache map[string]string) {
@@ -15,6 +16,11 @@ func (t *Token) initCache() (cache map[string]string) {
 	maps.Copy(cache, t.basic.CacheBalances())
 	maps.Copy(cache, t.basic.CacheTotalSupply())
 	maps.Copy(cache, t.cacheTokenInfo())
+	maps.Copy(cache, t.basic.CacheFrozen())
+	maps.Copy(cache, t.basic.CachePolicy())
+	maps.Copy(cache, t.cacheMintRecords())
+	maps.Copy(cache, t.cacheBurnRecords())
+	maps.Copy(cache, t.cacheFees())
 	return
 }
 
@@ -28,6 +34,39 @@ func (t *Token) cacheTokenInfo() map[string]string {
 	// Serialize source lock amounts map
 	sourceLockAmountsJson, _ := json.Marshal(t.db.GetSourceLockAmounts())
 
+	// Serialize mint record retention
+	mintRetentionJson, _ := json.Marshal(t.db.MintRetention())
+
+	// Serialize source token registry
+	sourceTokensJson, _ := json.Marshal(t.db.GetSourceTokens())
+
+	// Serialize relayer set
+	relayers, relayerThreshold := t.db.RelayerSet()
+	relayersJson, _ := json.Marshal(relayers)
+
+	// Serialize burn fee models
+	burnFeeModelsJson, _ := json.Marshal(t.db.GetBurnFeeModels())
+
+	// Serialize mint fee models
+	mintFeeModelsJson, _ := json.Marshal(t.db.GetMintFeeModels())
+
+	// Serialize fee withdrawal shares
+	feeSharesJson, _ := json.Marshal(t.db.FeeShares())
+
+	// Serialize burn routes
+	burnRoutesJson, _ := json.Marshal(t.db.BurnRoutes())
+
+	// Serialize reserve attestors and the latest reserve reports
+	reserveAttestorsJson, _ := json.Marshal(t.db.ReserveAttestors())
+	reservesJson, _ := json.Marshal(t.reserveStatuses(""))
+
+	// Serialize the invariant alerts halting chain types
+	haltedChainsJson, _ := json.Marshal(t.db.HaltedChains())
+
+	// Serialize the bridge limits and their usage over the rolling day
+	bridgeLimitsJson, _ := json.Marshal(t.db.BridgeLimits())
+	bridgeUsageJson, _ := json.Marshal(t.bridgeUsages())
+
 	info := t.basic.DB.Info()
 	cacheInfo := schema.CrossChainCacheInfo{
 		Name:              info.Name,
@@ -37,11 +76,29 @@ func (t *Token) cacheTokenInfo() map[string]string {
 		Description:       info.Description,
 		Owner:             t.basic.DB.Owner(),
 		MintOwner:         t.basic.DB.MintOwner(),
+		ComplianceOwner:   t.basic.DB.ComplianceOwner(),
 		BurnFees:          string(burnFeesJson),
+		BurnFeeModels:     string(burnFeeModelsJson),
+		MintFeeModels:     string(mintFeeModelsJson),
+		FeeShares:         string(feeSharesJson),
+		BurnRoutes:        string(burnRoutesJson),
 		FeeRecipient:      t.db.GetFeeRecipient(),
 		BurnProcessor:     t.db.GetBurnProcessor(),
 		SourceTokenChains: string(sourceTokenChainsJson),
 		SourceLockAmounts: string(sourceLockAmountsJson),
+		MintRetention:     string(mintRetentionJson),
+		BurnRecordMaxAge:  strconv.FormatInt(t.db.BurnRecordMaxAge(), 10),
+		BridgeAdmin:       t.db.GetBridgeAdmin(),
+		SourceTokens:      string(sourceTokensJson),
+		DustPolicy:        t.db.GetDustPolicy(),
+		Relayers:          string(relayersJson),
+		RelayerThreshold:  relayerThreshold,
+		ReserveAttestors:  string(reserveAttestorsJson),
+		ReserveTolerance:  t.db.ReserveTolerance(),
+		Reserves:          string(reservesJson),
+		HaltedChains:      string(haltedChainsJson),
+		BridgeLimits:      string(bridgeLimitsJson),
+		BridgeUsage:       string(bridgeUsageJson),
 	}
 
 	res, _ := json.Marshal(cacheInfo)
@@ -49,3 +106,29 @@ func (t *Token) cacheTokenInfo() map[string]string {
 		"info": string(res),
 	}
 }
+
+// cacheMintRecords caches the most recent mint records, newest first
+func (t *Token) cacheMintRecords() map[string]string {
+	records := t.db.MintRecords("")
+	recordsBy, _ := json.Marshal(recentRecords(records))
+	return map[string]string{
+		"mint-records": string(recordsBy),
+	}
+}
+
+// cacheBurnRecords caches the most recent burn records, newest first
+func (t *Token) cacheBurnRecords() map[string]string {
+	records := t.db.BurnRecords("")
+	recordsBy, _ := json.Marshal(recentRecords(records))
+	return map[string]string{
+		"burn-records": string(recordsBy),
+	}
+}
+
+// cacheFees caches the fee ledgers in chain type order
+func (t *Token) cacheFees() map[string]string {
+	feesBy, _ := json.Marshal(t.feeBalances(""))
+	return map[string]string{
+		"fees": string(feesBy),
+	}
+}
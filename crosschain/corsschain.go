@@ -42,6 +42,52 @@ func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
 		}
 	}
 
+	// Parse and validate the optional burn fee models
+	burnFeeModels := make(map[string]*schema.FeeModel)
+	if burnFeeModelsStr := env.Meta.Params["BurnFeeModels"]; burnFeeModelsStr != "" {
+		if burnFeeModels, err = parseFeeModels(burnFeeModelsStr); err != nil {
+			return
+		}
+	}
+
+	// Parse and validate the optional mint fee models
+	mintFeeModels := make(map[string]*schema.FeeModel)
+	if mintFeeModelsStr := env.Meta.Params["MintFeeModels"]; mintFeeModelsStr != "" {
+		if mintFeeModels, err = parseFeeModels(mintFeeModelsStr); err != nil {
+			return
+		}
+	}
+
+	// Parse and validate the optional fee withdrawal shares
+	var feeShares []schema.FeeShare
+	if feeSharesStr := env.Meta.Params["FeeShares"]; feeSharesStr != "" {
+		if feeShares, err = parseFeeShares(feeSharesStr); err != nil {
+			return
+		}
+	}
+
+	// Parse and validate the optional burn routes
+	burnRoutes := make(map[string]*schema.BurnRoute)
+	if burnRoutesStr := env.Meta.Params["BurnRoutes"]; burnRoutesStr != "" {
+		if burnRoutes, err = parseBurnRoutes(burnRoutesStr); err != nil {
+			return
+		}
+	}
+
+	// Parse and validate the optional reserve attestors and their shortfall tolerance
+	var reserveAttestors []string
+	if reserveAttestorsStr := env.Meta.Params["ReserveAttestors"]; reserveAttestorsStr != "" {
+		if reserveAttestors, err = parseReserveAttestors(reserveAttestorsStr); err != nil {
+			return
+		}
+	}
+	var reserveTolerance int64
+	if reserveToleranceStr := env.Meta.Params["ReserveTolerance"]; reserveToleranceStr != "" {
+		if reserveTolerance, err = parseReserveTolerance(reserveToleranceStr); err != nil {
+			return
+		}
+	}
+
 	// Parse and validate FeeRecipient with default value
 	feeRecipientStr := env.Meta.Params["FeeRecipient"]
 	if feeRecipientStr == "" {
@@ -64,6 +110,17 @@ func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
 		return
 	}
 
+	// Parse and validate ComplianceOwner with default value
+	complianceOwnerStr := env.Meta.Params["ComplianceOwner"]
+	if complianceOwnerStr == "" {
+		complianceOwnerStr = env.Meta.AccId // Default to owner
+	}
+	_, complianceOwner, err := utils.IDCheck(complianceOwnerStr)
+	if err != nil {
+		err = schema.ErrInvalidComplianceOwner
+		return
+	}
+
 	// Parse and validate BurnProcessor with default value
 	burnProcessorStr := env.Meta.Params["BurnProcessor"]
 	if burnProcessorStr == "" {
@@ -75,23 +132,79 @@ func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
 		return
 	}
 
+	// Parse and validate the optional BridgeAdmin
+	bridgeAdmin := ""
+	if bridgeAdminStr := env.Meta.Params["BridgeAdmin"]; bridgeAdminStr != "" {
+		if _, bridgeAdmin, err = utils.IDCheck(bridgeAdminStr); err != nil {
+			err = schema.ErrInvalidBridgeAdmin
+			return
+		}
+	}
+
+	// Parse and validate the optional DustPolicy
+	dustPolicy, err := parseDustPolicy(env.Meta.Params["DustPolicy"])
+	if err != nil {
+		return
+	}
+
+	// Parse and validate the optional mint record retention
+	mintRetention, err := parseMintRetention(env.Meta.Params["MintRecordMaxAge"], env.Meta.Params["MintRecordMaxBlocks"], schema.MintRetention{})
+	if err != nil {
+		return
+	}
+	var burnRecordMaxAge int64
+	if maxAgeStr := env.Meta.Params["BurnRecordMaxAge"]; maxAgeStr != "" {
+		if burnRecordMaxAge, err = parseBurnRecordMaxAge(maxAgeStr); err != nil {
+			return
+		}
+	}
+
+	// Parse and validate the optional transfer policy
+	var policy schema.Policy
+	if policyStr := env.Meta.Params["Policy"]; policyStr != "" {
+		if policy, err = basic.ParsePolicy(policyStr); err != nil {
+			return
+		}
+	}
+
+	basicDB := cache.NewBasicToken(schema.Info{
+		Id:          env.Meta.ItemId,
+		Name:        env.Meta.Params["Name"],
+		Ticker:      env.Meta.Params["Ticker"],
+		Decimals:    env.Meta.Params["Decimals"],
+		Logo:        env.Meta.Params["Logo"],
+		Description: env.Meta.Params["Description"],
+	}, env.Meta.AccId, mintOwner, complianceOwner, big.NewInt(0))
+	basicDB.SetPolicy(policy)
+
 	basicToken := &basic.Token{
-		DB: cache.NewBasicToken(schema.Info{
-			Id:          env.Meta.ItemId,
-			Name:        env.Meta.Params["Name"],
-			Ticker:      env.Meta.Params["Ticker"],
-			Decimals:    env.Meta.Params["Decimals"],
-			Logo:        env.Meta.Params["Logo"],
-			Description: env.Meta.Params["Description"],
-		}, env.Meta.AccId, mintOwner, big.NewInt(0)),
+		DB: basicDB,
+	}
+	ccDB := cache.NewCrossChainToken(burnFees, feeRecipient, burnProcessor)
+	ccDB.SetMintRetention(mintRetention)
+	ccDB.SetBurnRecordMaxAge(burnRecordMaxAge)
+	ccDB.SetBridgeAdmin(bridgeAdmin)
+	ccDB.SetDustPolicy(dustPolicy)
+	for key, model := range burnFeeModels {
+		ccDB.SetBurnFeeModel(key, model)
+	}
+	for key, model := range mintFeeModels {
+		ccDB.SetMintFeeModel(key, model)
+	}
+	ccDB.SetFeeShares(feeShares)
+	for chainType, route := range burnRoutes {
+		ccDB.SetBurnRoute(chainType, route)
 	}
+	ccDB.SetReserveAttestors(reserveAttestors)
+	ccDB.SetReserveTolerance(reserveTolerance)
 	return &Token{
 		basic: basicToken,
-		db:    cache.NewCrossChainToken(burnFees, feeRecipient, burnProcessor),
+		db:    ccDB,
 	}, nil
 }
 
 func (t *Token) Apply(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	t.basic.Now = meta.Timestamp
 	switch meta.Action {
 	case "Info":
 		res = t.handleInfo(from)
@@ -102,11 +215,101 @@ func (t *Token) Apply(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
 	case "Balance":
 		res = t.basic.HandleBalanceOf(from, meta.Params)
 	case "Transfer":
-		res = t.basic.HandleTransfer(meta.ItemId, from, meta.Params)
+		res = t.basic.Idempotent(from, meta, func() vmmSchema.Result {
+			return t.basic.HandleTransfer(meta.ItemId, from, meta.Params)
+		})
 	case "Mint":
-		res = t.handleCrossChainMint(from, meta.Params)
+		res = t.basic.Idempotent(from, meta, func() vmmSchema.Result {
+			return t.handleCrossChainMint(from, meta)
+		})
 	case "Burn":
-		res = t.handleCrossChainBurn(from, meta)
+		res = t.basic.Idempotent(from, meta, func() vmmSchema.Result {
+			return t.handleCrossChainBurn(from, meta)
+		})
+	case "Quote-Burn":
+		res = t.handleQuoteBurn(from, meta.Params)
+	case "Withdraw-Fees":
+		res = t.handleWithdrawFees(from, meta.Params)
+	case "Fees":
+		res = t.handleFees(from, meta.Params)
+	case "Adjust-Lock-Amount":
+		res = t.handleAdjustLockAmount(from, meta)
+	case "Move-Lock-Amount":
+		res = t.handleMoveLockAmount(from, meta)
+	case "Lock-Audit-Log":
+		res = t.handleLockAuditLog(from, meta.Params)
+	case "Reserve-Report":
+		res = t.handleReserveReport(from, meta.Params)
+	case "Reserves":
+		res = t.handleReserves(from, meta.Params)
+	case "Acknowledge-Alert":
+		res = t.handleAcknowledgeAlert(from, meta.Params)
+	case "Reset-Mint-Watermark":
+		res = t.handleResetMintWatermark(from, meta.Params)
+	case "Set-Bridge-Limits":
+		res = t.handleSetBridgeLimits(from, meta.Params)
+	case "Approve-Hold":
+		res = t.basic.HandleApproveHold(from, meta.Params)
+	case "Hold":
+		res = t.basic.HandleHold(meta.ItemId, from, meta.Params)
+	case "Capture-Hold":
+		res = t.basic.HandleCaptureHold(meta.ItemId, from, meta.Params)
+	case "Release-Hold":
+		res = t.basic.HandleReleaseHold(from, meta.Params)
+	case "Freeze-Account":
+		res = t.basic.HandleFreezeAccount(meta.ItemId, from, meta.Params, true)
+	case "Unfreeze-Account":
+		res = t.basic.HandleFreezeAccount(meta.ItemId, from, meta.Params, false)
+	case "Force-Transfer":
+		res = t.basic.HandleForceTransfer(meta.ItemId, from, meta.Params)
+	case "Is-Frozen":
+		res = t.basic.HandleIsFrozen(from, meta.Params)
+	case "Set-Policy":
+		res = t.basic.HandleSetPolicy(from, meta.Params)
+	case "Policy":
+		res = t.basic.HandlePolicy(from)
+	case "Distribute":
+		res = t.basic.HandleDistribute(from, meta.Params)
+	case "Credit-Notice":
+		res = t.basic.HandleDistributeCredit(from, meta.Params)
+	case "Claim-Rewards":
+		res = t.basic.HandleClaimRewards(meta.ItemId, from, meta.Params)
+	case "Rewards":
+		res = t.basic.HandleRewards(from, meta.Params)
+	case "Set-Reward-Exclusions":
+		res = t.basic.HandleSetRewardExclusions(from, meta.Params)
+	case "Set-Reward-Tokens":
+		res = t.basic.HandleSetRewardTokens(from, meta.Params)
+	case "TWAB":
+		res = t.basic.HandleTwab(from, meta.Params)
+	case "Total-Supply-TWAB":
+		res = t.basic.HandleTotalSupplyTwab(from, meta.Params)
+	case "Mint-Record":
+		res = t.handleMintRecord(from, meta.Params)
+	case "Mint-Records":
+		res = t.handleMintRecords(from, meta.Params)
+	case "Burn-Record":
+		res = t.handleBurnRecord(from, meta.Params)
+	case "Burn-Records":
+		res = t.handleBurnRecords(from, meta.Params)
+	case "Attest-Mint":
+		res = t.handleAttestMint(from, meta)
+	case "Clear-Mint-Attestation":
+		res = t.handleClearMintAttestation(from, meta.Params)
+	case "Mint-Attestation":
+		res = t.handleMintAttestation(from, meta.Params)
+	case "Register-Source-Token":
+		res = t.handleRegisterSourceToken(from, meta.Params)
+	case "Update-Source-Token":
+		res = t.handleUpdateSourceToken(from, meta.Params)
+	case "Disable-Source-Token":
+		res = t.handleDisableSourceToken(from, meta.Params)
+	case "Burn-Complete":
+		res = t.handleBurnComplete(from, meta.Params)
+	case "Burn-Refund":
+		res = t.handleBurnRefund(from, meta.Params)
+	case "Pending-Burns":
+		res = t.handlePendingBurns(from, meta.Params)
 	}
 	return
 }
@@ -146,6 +349,8 @@ func (t *Token) Restore(data string) error {
 	if err := t.db.Restore(snap["cc"]); err != nil {
 		return err
 	}
+	// Tx hashes minted before their normalization are re-keyed, a replay in the canonical form is rejected
+	t.db.CanonicalizeMintTxHashes(canonicalMintTxHash)
 	return nil
 }
 
@@ -0,0 +1,89 @@
+package crosschain
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// parseDustPolicy validates the DustPolicy param, empty defaults to reject
+func parseDustPolicy(dustPolicy string) (string, error) {
+	switch dustPolicy {
+	case "":
+		return schema.DustPolicyReject, nil
+	case schema.DustPolicyReject, schema.DustPolicyFee:
+		return dustPolicy, nil
+	}
+	return "", schema.ErrInvalidDustPolicy
+}
+
+// checkDecimalsChange validates new wrapped token decimals, they rescale every conversion
+// so they can only change while there is no supply and nothing is locked
+func (t *Token) checkDecimalsChange(decimalsStr string) error {
+	if decimals, err := strconv.Atoi(decimalsStr); err != nil || decimals < 0 {
+		return schema.ErrInvalidDecimals
+	}
+	if t.basic.DB.GetTotalSupply().Sign() != 0 {
+		return schema.ErrDecimalsLocked
+	}
+	for _, lockAmount := range t.db.GetSourceLockAmounts() {
+		if lockAmount.Sign() != 0 {
+			return schema.ErrDecimalsLocked
+		}
+	}
+	return nil
+}
+
+// decimalsShift returns the wrapped token decimals minus the source token decimals,
+// a source token registered without decimals uses the wrapped units
+func (t *Token) decimalsShift(sourceToken schema.SourceToken) (int, error) {
+	if sourceToken.Decimals == "" {
+		return 0, nil
+	}
+	sourceDecimals, err := strconv.Atoi(sourceToken.Decimals)
+	if err != nil {
+		return 0, schema.ErrInvalidSourceToken
+	}
+	wrappedDecimals, err := strconv.Atoi(t.basic.DB.Info().Decimals)
+	if err != nil {
+		return 0, schema.ErrIncorrectTokenInfo
+	}
+	return wrappedDecimals - sourceDecimals, nil
+}
+
+// scaleDown divides an amount by 10^decimals, the remainder is the dust
+func scaleDown(amount *big.Int, decimals int) (scaled, dust *big.Int) {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Int).QuoRem(amount, factor, new(big.Int))
+}
+
+// scaleUp multiplies an amount by 10^decimals
+func scaleUp(amount *big.Int, decimals int) *big.Int {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return factor.Mul(factor, amount)
+}
+
+// toWrapped converts a source amount into wrapped units, the dust is in source units
+func toWrapped(sourceAmount *big.Int, shift int) (amount, dust *big.Int) {
+	if shift >= 0 {
+		return scaleUp(sourceAmount, shift), big.NewInt(0)
+	}
+	return scaleDown(sourceAmount, -shift)
+}
+
+// toSource converts a wrapped amount into source units, the dust is in wrapped units
+func toSource(amount *big.Int, shift int) (sourceAmount, dust *big.Int) {
+	if shift <= 0 {
+		return scaleUp(amount, -shift), big.NewInt(0)
+	}
+	return scaleDown(amount, shift)
+}
+
+// checkDust rejects an amount with dust unless the dust policy keeps it as a fee
+func (t *Token) checkDust(dust *big.Int) error {
+	if dust.Sign() > 0 && t.db.GetDustPolicy() != schema.DustPolicyFee {
+		return schema.ErrDustAmount
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package crosschain
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// MaxFeeBps is the basis points of a fee taking the whole amount
+const MaxFeeBps = 10000
+
+// parseFeeAmount parses an optional non-negative fee amount, empty is zero
+func parseFeeAmount(amountStr string) (*big.Int, bool) {
+	if amountStr == "" {
+		return big.NewInt(0), true
+	}
+	amount, ok := new(big.Int).SetString(amountStr, 10)
+	return amount, ok && amount.Sign() >= 0
+}
+
+// validateFeeModel checks the amounts, the basis points and the tier order of a fee model
+func validateFeeModel(model schema.FeeModel) error {
+	_, flatOk := parseFeeAmount(model.Flat)
+	minFee, minOk := parseFeeAmount(model.Min)
+	maxFee, maxOk := parseFeeAmount(model.Max)
+	if !flatOk || !minOk || !maxOk || model.Bps < 0 || model.Bps > MaxFeeBps {
+		return schema.ErrInvalidFeeModel
+	}
+	if model.Max != "" && maxFee.Cmp(minFee) < 0 {
+		return schema.ErrInvalidFeeModel
+	}
+
+	var prev *big.Int
+	for _, tier := range model.Tiers {
+		from, ok := new(big.Int).SetString(tier.From, 10)
+		if !ok || from.Sign() < 0 || (prev != nil && from.Cmp(prev) <= 0) {
+			return schema.ErrInvalidFeeModel
+		}
+		if _, ok = parseFeeAmount(tier.Flat); !ok || tier.Bps < 0 || tier.Bps > MaxFeeBps {
+			return schema.ErrInvalidFeeModel
+		}
+		prev = from
+	}
+	return nil
+}
+
+// parseFeeModels strictly decodes the fee models keyed by chainType or chainType:tokenId,
+// a null model removes the key
+func parseFeeModels(modelsStr string) (map[string]*schema.FeeModel, error) {
+	models := make(map[string]*schema.FeeModel)
+	decoder := json.NewDecoder(bytes.NewReader([]byte(modelsStr)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&models); err != nil {
+		return nil, schema.ErrInvalidFeeModel
+	}
+	for key, model := range models {
+		if key == "" {
+			return nil, schema.ErrInvalidFeeModel
+		}
+		if model == nil {
+			continue
+		}
+		if err := validateFeeModel(*model); err != nil {
+			return nil, err
+		}
+	}
+	return models, nil
+}
+
+// computeFee applies a validated fee model to an amount
+func computeFee(model schema.FeeModel, amount *big.Int) *big.Int {
+	flat, bps := model.Flat, model.Bps
+	for _, tier := range model.Tiers {
+		from, _ := new(big.Int).SetString(tier.From, 10)
+		if amount.Cmp(from) < 0 {
+			break
+		}
+		flat, bps = tier.Flat, tier.Bps
+	}
+
+	fee, _ := parseFeeAmount(flat)
+	variable := new(big.Int).Mul(amount, big.NewInt(bps))
+	fee.Add(fee, variable.Quo(variable, big.NewInt(MaxFeeBps)))
+
+	if minFee, _ := parseFeeAmount(model.Min); fee.Cmp(minFee) < 0 {
+		fee = minFee
+	}
+	if model.Max != "" {
+		if maxFee, _ := parseFeeAmount(model.Max); fee.Cmp(maxFee) > 0 {
+			fee = maxFee
+		}
+	}
+	return fee
+}
+
+// tokenFeeModel returns the fee model of a token, else of its chain type
+func tokenFeeModel(getFeeModel func(key string) (schema.FeeModel, bool), token schema.SourceToken) (schema.FeeModel, bool) {
+	if model, ok := getFeeModel(token.ChainType + ":" + token.TokenId); ok {
+		return model, true
+	}
+	return getFeeModel(token.ChainType)
+}
+
+// burnFee returns the fee of a burn, from the fee model of the target token, else of its chain type,
+// else the flat BurnFees of the chain type
+func (t *Token) burnFee(targetToken schema.SourceToken, amount *big.Int) (*big.Int, error) {
+	if model, ok := tokenFeeModel(t.db.GetBurnFeeModel, targetToken); ok {
+		return computeFee(model, amount), nil
+	}
+	burnFee, ok := t.db.GetBurnFee(targetToken.ChainType)
+	if !ok {
+		return nil, schema.ErrMissingBurnFee
+	}
+	return burnFee, nil
+}
+
+// mintFee returns the fee of a mint in wrapped units, from the fee model of the source token,
+// else of its chain type. Mints are free without a model.
+func (t *Token) mintFee(sourceToken schema.SourceToken, amount *big.Int) *big.Int {
+	if model, ok := tokenFeeModel(t.db.GetMintFeeModel, sourceToken); ok {
+		return computeFee(model, amount)
+	}
+	return big.NewInt(0)
+}
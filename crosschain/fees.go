@@ -0,0 +1,233 @@
+package crosschain
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// feeBalance is the fee ledger of a chain type as returned by the Fees query
+type feeBalance struct {
+	ChainType string
+	Accrued   string
+	Withdrawn string
+	Available string
+}
+
+// parseFeeShares strictly decodes the FeeShares JSON array, an empty array pays the fee recipient
+func parseFeeShares(sharesStr string) ([]schema.FeeShare, error) {
+	var shares []schema.FeeShare
+	decoder := json.NewDecoder(bytes.NewReader([]byte(sharesStr)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&shares); err != nil {
+		return nil, schema.ErrInvalidFeeShares
+	}
+	var err error
+	for i, share := range shares {
+		if _, shares[i].Recipient, err = utils.IDCheck(share.Recipient); err != nil || share.Share <= 0 {
+			return nil, schema.ErrInvalidFeeShares
+		}
+		if slices.ContainsFunc(shares[:i], func(s schema.FeeShare) bool { return s.Recipient == shares[i].Recipient }) {
+			return nil, schema.ErrInvalidFeeShares
+		}
+	}
+	return shares, nil
+}
+
+// available returns the fees of a ledger that can be withdrawn or refunded
+func available(ledger schema.FeeLedger) *big.Int {
+	return new(big.Int).Sub(ledger.Accrued, ledger.Withdrawn)
+}
+
+// accrueFee adds the fee of a mint or a burn to the ledger of its chain type
+func (t *Token) accrueFee(chainType string, fee *big.Int) {
+	if fee.Sign() == 0 {
+		return
+	}
+	ledger := t.db.FeeLedger(chainType)
+	ledger.Accrued.Add(ledger.Accrued, fee)
+	t.db.SetFeeLedger(chainType, ledger)
+}
+
+// refundFee takes back a fee of the ledger of a chain type
+func (t *Token) refundFee(chainType string, fee *big.Int) error {
+	ledger := t.db.FeeLedger(chainType)
+	if available(ledger).Cmp(fee) < 0 {
+		return schema.ErrInsufficientFees
+	}
+	ledger.Accrued.Sub(ledger.Accrued, fee)
+	t.db.SetFeeLedger(chainType, ledger)
+	return nil
+}
+
+// feeShares returns the recipients of the fee withdrawals, the fee recipient without FeeShares
+func (t *Token) feeShares() []schema.FeeShare {
+	if shares := t.db.FeeShares(); len(shares) > 0 {
+		return shares
+	}
+	return []schema.FeeShare{{Recipient: t.db.GetFeeRecipient(), Share: 1}}
+}
+
+// handleWithdrawFees pays the available fees of ChainType, or of every chain type, to the fee shares
+// (Owner only). The rounding remainder of the shares stays accrued.
+func (t *Token) handleWithdrawFees(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != t.basic.DB.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	ledgers := t.db.FeeLedgers()
+	chainTypes := slices.Sorted(maps.Keys(ledgers))
+	chainType := params["ChainType"]
+	if chainType != "" {
+		chainTypes = []string{chainType}
+		ledgers[chainType] = t.db.FeeLedger(chainType)
+	}
+
+	// Quantity withdraws part of the fees of a single chain type
+	var quantity *big.Int
+	if params["Quantity"] != "" {
+		var ok bool
+		if quantity, ok = new(big.Int).SetString(params["Quantity"], 10); !ok || quantity.Sign() <= 0 {
+			res.Error = schema.ErrInvalidQuantityFormat
+			return
+		}
+		if chainType == "" {
+			res.Error = schema.ErrIncorrectQuantity
+			return
+		}
+		if available(ledgers[chainType]).Cmp(quantity) < 0 {
+			res.Error = schema.ErrInsufficientFees
+			return
+		}
+	}
+
+	shares := t.feeShares()
+	totalShares := int64(0)
+	for _, share := range shares {
+		totalShares += share.Share
+	}
+	payouts := make([]*big.Int, len(shares))
+	for i := range payouts {
+		payouts[i] = big.NewInt(0)
+	}
+	total := big.NewInt(0)
+	withdrawn := make(map[string]*big.Int)
+	for _, ct := range chainTypes {
+		amount := quantity
+		if amount == nil {
+			amount = available(ledgers[ct])
+		}
+		paid := big.NewInt(0)
+		for i, share := range shares {
+			payout := new(big.Int).Mul(amount, big.NewInt(share.Share))
+			payout.Quo(payout, big.NewInt(totalShares))
+			payouts[i].Add(payouts[i], payout)
+			paid.Add(paid, payout)
+		}
+		if paid.Sign() == 0 {
+			continue
+		}
+		withdrawn[ct] = paid
+		total.Add(total, paid)
+	}
+	if total.Sign() == 0 {
+		res.Error = schema.ErrInsufficientFees
+		return
+	}
+
+	// Withdrawn fees return into the supply
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Withdraw-Fees-Notice"},
+				{Name: "ChainType", Value: chainType},
+				{Name: "Quantity", Value: total.String()},
+				{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+			},
+		},
+	}
+	// The ledgers only change once every recipient is credited, a failed credit restores the balances
+	// of the earlier recipients regardless of their holds
+	balances := make([]*big.Int, len(shares))
+	for i, share := range shares {
+		balance, err := t.basic.DB.BalanceOf(share.Recipient)
+		if err != nil {
+			res.Messages = nil
+			res.Error = err
+			return
+		}
+		balances[i] = balance
+	}
+	recipients := make([]string, 0, len(shares))
+	for i, share := range shares {
+		if payouts[i].Sign() == 0 {
+			continue
+		}
+		if err := t.basic.Add(share.Recipient, payouts[i]); err != nil {
+			for j := range i {
+				if restoreErr := t.basic.RestoreBalance(shares[j].Recipient, balances[j]); restoreErr != nil {
+					err = restoreErr
+				}
+			}
+			res.Messages = nil
+			res.Error = err
+			return
+		}
+		recipients = append(recipients, share.Recipient)
+		res.Messages = append(res.Messages, &vmmSchema.ResMessage{
+			Target: share.Recipient,
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Fee-Withdrawal-Notice"},
+				{Name: "ChainType", Value: chainType},
+				{Name: "Quantity", Value: payouts[i].String()},
+				{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+			},
+		})
+	}
+	for ct, paid := range withdrawn {
+		ledger := ledgers[ct]
+		ledger.Withdrawn.Add(ledger.Withdrawn, paid)
+		t.db.SetFeeLedger(ct, ledger)
+	}
+	t.basic.SetTotalSupply(new(big.Int).Add(t.basic.DB.GetTotalSupply(), total))
+
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.basic.CacheChangeBalance(recipients...))
+	maps.Copy(res.Cache, t.basic.CacheTotalSupply())
+	maps.Copy(res.Cache, t.cacheFees())
+	return
+}
+
+// feeBalances returns the fee ledgers in chain type order, optionally filtered by chain type
+func (t *Token) feeBalances(chainType string) []feeBalance {
+	ledgers := t.db.FeeLedgers()
+	balances := make([]feeBalance, 0, len(ledgers))
+	for _, ct := range slices.Sorted(maps.Keys(ledgers)) {
+		if chainType != "" && ct != chainType {
+			continue
+		}
+		ledger := ledgers[ct]
+		balances = append(balances, feeBalance{
+			ChainType: ct,
+			Accrued:   ledger.Accrued.String(),
+			Withdrawn: ledger.Withdrawn.String(),
+			Available: available(ledger).String(),
+		})
+	}
+	return balances
+}
+
+// handleFees returns the accrued, withdrawn and available fees per chain type as JSON,
+// optionally filtered by ChainType
+func (t *Token) handleFees(from string, params map[string]string) (res vmmSchema.Result) {
+	return t.recordsResult(from, "Fees", t.feeBalances(params["ChainType"]), "", params)
+}
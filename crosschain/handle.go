@@ -2,8 +2,10 @@ package crosschain
 
 import (
 	"encoding/json"
+	"fmt"
 	"maps"
 	"math/big"
+	"strconv"
 
 	"github.com/aox-labs/hymx-vmtoken/schema"
 	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
@@ -16,6 +18,19 @@ func (t *Token) handleInfo(from string) (res vmmSchema.Result) {
 	burnFeesJson, _ := json.Marshal(t.db.GetBurnFees())
 	sourceTokenChainsJson, _ := json.Marshal(t.db.GetSourceTokenChains())
 	sourceLockAmountsJson, _ := json.Marshal(t.db.GetSourceLockAmounts())
+	mintRetentionJson, _ := json.Marshal(t.db.MintRetention())
+	sourceTokensJson, _ := json.Marshal(t.db.GetSourceTokens())
+	relayers, relayerThreshold := t.db.RelayerSet()
+	relayersJson, _ := json.Marshal(relayers)
+	burnFeeModelsJson, _ := json.Marshal(t.db.GetBurnFeeModels())
+	mintFeeModelsJson, _ := json.Marshal(t.db.GetMintFeeModels())
+	feeSharesJson, _ := json.Marshal(t.db.FeeShares())
+	burnRoutesJson, _ := json.Marshal(t.db.BurnRoutes())
+	reserveAttestorsJson, _ := json.Marshal(t.db.ReserveAttestors())
+	reservesJson, _ := json.Marshal(t.reserveStatuses(""))
+	haltedChainsJson, _ := json.Marshal(t.db.HaltedChains())
+	bridgeLimitsJson, _ := json.Marshal(t.db.BridgeLimits())
+	bridgeUsageJson, _ := json.Marshal(t.bridgeUsages())
 	feeRecipient := t.db.GetFeeRecipient()
 	burnProcessor := t.db.GetBurnProcessor()
 
@@ -29,11 +44,29 @@ func (t *Token) handleInfo(from string) (res vmmSchema.Result) {
 		{Name: "Description", Value: info.Description},
 		{Name: "Owner", Value: t.basic.DB.Owner()},
 		{Name: "MintOwner", Value: t.basic.DB.MintOwner()},
+		{Name: "ComplianceOwner", Value: t.basic.DB.ComplianceOwner()},
 		{Name: "BurnFees", Value: string(burnFeesJson)},
+		{Name: "BurnFeeModels", Value: string(burnFeeModelsJson)},
+		{Name: "MintFeeModels", Value: string(mintFeeModelsJson)},
+		{Name: "FeeShares", Value: string(feeSharesJson)},
+		{Name: "BurnRoutes", Value: string(burnRoutesJson)},
 		{Name: "FeeRecipient", Value: feeRecipient},
 		{Name: "BurnProcessor", Value: burnProcessor},
 		{Name: "SourceTokenChains", Value: string(sourceTokenChainsJson)},
 		{Name: "SourceLockAmounts", Value: string(sourceLockAmountsJson)},
+		{Name: "MintRetention", Value: string(mintRetentionJson)},
+		{Name: "BurnRecordMaxAge", Value: strconv.FormatInt(t.db.BurnRecordMaxAge(), 10)},
+		{Name: "BridgeAdmin", Value: t.db.GetBridgeAdmin()},
+		{Name: "SourceTokens", Value: string(sourceTokensJson)},
+		{Name: "DustPolicy", Value: t.db.GetDustPolicy()},
+		{Name: "Relayers", Value: string(relayersJson)},
+		{Name: "RelayerThreshold", Value: strconv.Itoa(relayerThreshold)},
+		{Name: "ReserveAttestors", Value: string(reserveAttestorsJson)},
+		{Name: "ReserveTolerance", Value: strconv.FormatInt(t.db.ReserveTolerance(), 10)},
+		{Name: "Reserves", Value: string(reservesJson)},
+		{Name: "HaltedChains", Value: string(haltedChainsJson)},
+		{Name: "BridgeLimits", Value: string(bridgeLimitsJson)},
+		{Name: "BridgeUsage", Value: string(bridgeUsageJson)},
 	}
 
 	res.Messages = []*vmmSchema.ResMessage{
@@ -72,6 +105,15 @@ func (t *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema
 		t.basic.DB.SetMintOwner(newOwner)
 	}
 
+	if meta.Params["ComplianceOwner"] != "" {
+		_, newOwner, err := utils.IDCheck(meta.Params["ComplianceOwner"])
+		if err != nil {
+			res.Error = schema.ErrInvalidComplianceOwner
+			return
+		}
+		t.basic.DB.SetComplianceOwner(newOwner)
+	}
+
 	info := t.basic.DB.Info()
 	if meta.Params["Name"] != "" {
 		info.Name = meta.Params["Name"]
@@ -81,8 +123,12 @@ func (t *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema
 		info.Ticker = meta.Params["Ticker"]
 	}
 
-	if meta.Params["Decimals"] != "" {
-		info.Decimals = meta.Params["Decimals"]
+	if decimals := meta.Params["Decimals"]; decimals != "" && decimals != info.Decimals {
+		if err := t.checkDecimalsChange(decimals); err != nil {
+			res.Error = err
+			return
+		}
+		info.Decimals = decimals
 	}
 
 	if meta.Params["Logo"] != "" {
@@ -116,6 +162,66 @@ func (t *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema
 		}
 	}
 
+	if meta.Params["BurnFeeModels"] != "" {
+		burnFeeModels, err := parseFeeModels(meta.Params["BurnFeeModels"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		for key, model := range burnFeeModels {
+			t.db.SetBurnFeeModel(key, model)
+		}
+	}
+
+	if meta.Params["MintFeeModels"] != "" {
+		mintFeeModels, err := parseFeeModels(meta.Params["MintFeeModels"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		for key, model := range mintFeeModels {
+			t.db.SetMintFeeModel(key, model)
+		}
+	}
+
+	if meta.Params["FeeShares"] != "" {
+		feeShares, err := parseFeeShares(meta.Params["FeeShares"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetFeeShares(feeShares)
+	}
+
+	if meta.Params["BurnRoutes"] != "" {
+		burnRoutes, err := parseBurnRoutes(meta.Params["BurnRoutes"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		for chainType, route := range burnRoutes {
+			t.db.SetBurnRoute(chainType, route)
+		}
+	}
+
+	if meta.Params["ReserveAttestors"] != "" {
+		reserveAttestors, err := parseReserveAttestors(meta.Params["ReserveAttestors"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetReserveAttestors(reserveAttestors)
+	}
+
+	if meta.Params["ReserveTolerance"] != "" {
+		reserveTolerance, err := parseReserveTolerance(meta.Params["ReserveTolerance"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetReserveTolerance(reserveTolerance)
+	}
+
 	if meta.Params["BurnProcessor"] != "" {
 		_, burnProcessor, err := utils.IDCheck(meta.Params["BurnProcessor"])
 		if err != nil {
@@ -125,6 +231,56 @@ func (t *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema
 		t.db.SetBurnProcessor(burnProcessor)
 	}
 
+	if meta.Params["BridgeAdmin"] != "" {
+		_, bridgeAdmin, err := utils.IDCheck(meta.Params["BridgeAdmin"])
+		if err != nil {
+			res.Error = schema.ErrInvalidBridgeAdmin
+			return
+		}
+		t.db.SetBridgeAdmin(bridgeAdmin)
+	}
+
+	if meta.Params["DustPolicy"] != "" {
+		dustPolicy, err := parseDustPolicy(meta.Params["DustPolicy"])
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetDustPolicy(dustPolicy)
+	}
+
+	relayersStr, thresholdStr := meta.Params["Relayers"], meta.Params["RelayerThreshold"]
+	if relayersStr != "" || thresholdStr != "" {
+		relayers, threshold := t.db.RelayerSet()
+		relayers, threshold, err := parseRelayerSet(relayersStr, thresholdStr, relayers, threshold)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetRelayerSet(relayers, threshold)
+	}
+
+	maxAgeStr, maxBlocksStr := meta.Params["MintRecordMaxAge"], meta.Params["MintRecordMaxBlocks"]
+	if maxAgeStr != "" || maxBlocksStr != "" {
+		retention, err := parseMintRetention(maxAgeStr, maxBlocksStr, t.db.MintRetention())
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetMintRetention(retention)
+		t.db.PruneMintedRecords(t.basic.Now)
+	}
+
+	if maxAgeStr := meta.Params["BurnRecordMaxAge"]; maxAgeStr != "" {
+		maxAge, err := parseBurnRecordMaxAge(maxAgeStr)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		t.db.SetBurnRecordMaxAge(maxAge)
+		t.db.PruneBurnRecords(t.basic.Now)
+	}
+
 	res.Messages = []*vmmSchema.ResMessage{
 		{
 			Target: from,
@@ -133,22 +289,31 @@ func (t *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema
 			},
 		},
 	}
-	res.Cache = t.cacheTokenInfo()
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	maps.Copy(res.Cache, t.cacheMintRecords())
+	maps.Copy(res.Cache, t.cacheBurnRecords())
 	return
 }
 
-func (t *Token) handleCrossChainMint(from string, params map[string]string) (res vmmSchema.Result) {
+func (t *Token) handleCrossChainMint(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
 	// Check minting permission
 	if from != t.basic.DB.MintOwner() {
 		res.Error = schema.ErrIncorrectOwner
 		return
 	}
 
-	if _, ok := t.db.GetMintedRecord(params["X-MintTxHash"]); ok {
-		res.Error = schema.ErrRepeatMint
+	// With relayers, mints only execute through their attestations
+	if _, threshold := t.db.RelayerSet(); threshold > 0 {
+		res.Error = schema.ErrAttestationRequired
 		return
 	}
+	return t.crossChainMint(from, meta)
+}
 
+// crossChainMint mints the source amount locked on the source chain, once authorized
+func (t *Token) crossChainMint(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	params := meta.Params
 	// Parse and validate recipient
 	recipient, exists := params["Recipient"]
 	if !exists {
@@ -169,7 +334,8 @@ func (t *Token) handleCrossChainMint(from string, params map[string]string) (res
 		return
 	}
 
-	amount, ok := new(big.Int).SetString(quantity, 10)
+	// Quantity is in source units
+	sourceAmount, ok := new(big.Int).SetString(quantity, 10)
 	if !ok {
 		res.Error = schema.ErrInvalidQuantityFormat
 		return
@@ -194,29 +360,84 @@ func (t *Token) handleCrossChainMint(from string, params map[string]string) (res
 		return
 	}
 
+	mintTxHash, err := parseMintTxHash(sourceChainType, params["X-MintTxHash"])
+	if err != nil {
+		res.Error = err
+		return
+	}
+	blockHeight, err := t.checkMintReplay(mintTxHash, sourceChainType, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
 	// verify chainType and tokenId
-	chainType, ok := t.db.GetSourceTokenChain(sourceTokenId)
-	if !ok {
-		t.db.SetSourceTokenChain(sourceTokenId, sourceChainType)
-	} else {
-		if chainType != sourceChainType {
-			res.Error = schema.ErrIncorrectSourceChainType
-			return
-		}
+	sourceToken, registered := t.db.GetSourceToken(sourceTokenId)
+	if !registered {
+		res.Error = schema.ErrUnregisteredSourceToken
+		return
 	}
-	// change balances
-	err = t.basic.Mint(recipient, amount)
+	if sourceToken.Disabled {
+		res.Error = schema.ErrSourceTokenDisabled
+		return
+	}
+	if sourceToken.ChainType != sourceChainType {
+		res.Error = schema.ErrIncorrectSourceChainType
+		return
+	}
+	if err = t.checkHalted(sourceChainType); err != nil {
+		res.Error = err
+		return
+	}
+	// A reserve shortfall reported by an attestor pauses the mints of the source token
+	if report, ok := t.db.ReserveReport(sourceTokenId); ok && report.MintPaused {
+		res.Error = schema.ErrReserveShortfall
+		return
+	}
+
+	// Convert into wrapped units, the dust stays locked on the source chain
+	shift, err := t.decimalsShift(sourceToken)
 	if err != nil {
 		res.Error = err
 		return
 	}
+	amount, dust := toWrapped(sourceAmount, shift)
+	if err = t.checkDust(dust); err != nil {
+		res.Error = err
+		return
+	}
+	if amount.Sign() == 0 && dust.Sign() > 0 {
+		res.Error = schema.ErrDustAmount
+		return
+	}
+
+	if err = t.checkMintLimits(sourceToken, amount); err != nil {
+		res.Error = err
+		return
+	}
 
-	// change lock amount
+	// The mint fee is taken from the wrapped amount
+	fee := t.mintFee(sourceToken, amount)
+	if fee.Cmp(amount) > 0 {
+		res.Error = schema.ErrIncorrectQuantity
+		return
+	}
+	netAmount := new(big.Int).Sub(amount, fee)
+
+	// change balances, the fee accrues in the fee ledger out of the supply
+	err = t.basic.Mint(recipient, netAmount)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	t.accrueFee(sourceChainType, fee)
+	t.recordMint(sourceToken, amount)
+	// change lock amount, tracked in source units
 	curLockAmt, ok := t.db.GetSourceLockAmount(sourceTokenId, sourceChainType)
 	if !ok {
 		curLockAmt = big.NewInt(0)
 	}
-	t.db.SetSourceLockAmount(sourceTokenId, sourceChainType, new(big.Int).Add(curLockAmt, amount))
+	t.db.SetSourceLockAmount(sourceTokenId, sourceChainType, new(big.Int).Add(curLockAmt, sourceAmount))
 
 	// Create mint notice for owner
 	ownerNotice := &vmmSchema.ResMessage{
@@ -224,11 +445,13 @@ func (t *Token) handleCrossChainMint(from string, params map[string]string) (res
 		Tags: []goarSchema.Tag{
 			{Name: "Action", Value: "Mint-Notice"},
 			{Name: "Recipient", Value: recipient},
-			{Name: "Quantity", Value: quantity},
+			{Name: "Quantity", Value: netAmount.String()},
+			{Name: "SourceQuantity", Value: quantity},
+			{Name: "Fee", Value: fee.String()},
 			{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
 			{Name: "SourceChainType", Value: sourceChainType},
 			{Name: "SourceTokenId", Value: sourceTokenId},
-			{Name: "X-MintTxHash", Value: params["X-MintTxHash"]},
+			{Name: "X-MintTxHash", Value: mintTxHash},
 		},
 	}
 
@@ -238,151 +461,139 @@ func (t *Token) handleCrossChainMint(from string, params map[string]string) (res
 		Tags: []goarSchema.Tag{
 			{Name: "Action", Value: "Mint-Notice"},
 			{Name: "Recipient", Value: recipient},
-			{Name: "Quantity", Value: quantity},
+			{Name: "Quantity", Value: netAmount.String()},
+			{Name: "SourceQuantity", Value: quantity},
+			{Name: "Fee", Value: fee.String()},
 			{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
 			{Name: "SourceChainType", Value: sourceChainType},
 			{Name: "SourceTokenId", Value: sourceTokenId},
-			{Name: "X-MintTxHash", Value: params["X-MintTxHash"]},
+			{Name: "X-MintTxHash", Value: mintTxHash},
 		},
 	}
 
-	t.db.SetMintedRecord(params["X-MintTxHash"], sourceChainType)
+	t.db.AddMintedRecord(schema.MintRecord{
+		MintTxHash:      mintTxHash,
+		SourceChainType: sourceChainType,
+		SourceTokenId:   sourceTokenId,
+		Recipient:       recipient,
+		Quantity:        netAmount.String(),
+		SourceQuantity:  sourceAmount.String(),
+		Dust:            dust.String(),
+		Fee:             fee.String(),
+		BlockHeight:     blockHeight,
+		Timestamp:       t.basic.Now,
+		MessageId:       meta.ItemId,
+	})
+	t.db.PruneMintedRecords(t.basic.Now)
 
 	res.Messages = []*vmmSchema.ResMessage{ownerNotice, recipientNotice}
+	res.Messages = append(res.Messages, t.monitorInvariants("Mint", meta.ItemId, sourceChainType)...)
 	res.Cache = map[string]string{}
 	maps.Copy(res.Cache, t.cacheTokenInfo())
 	maps.Copy(res.Cache, t.basic.CacheTotalSupply())
 	maps.Copy(res.Cache, t.basic.CacheChangeBalance(recipient))
+	maps.Copy(res.Cache, t.cacheMintRecords())
+	maps.Copy(res.Cache, t.cacheFees())
 	return
 }
 
-// handleCrossChainBurn handles cross-chain burning with target chain selection
+// handleCrossChainBurn handles cross-chain burning with target chain selection. A burn routed
+// across several source tokens records and notifies each leg separately.
 func (t *Token) handleCrossChainBurn(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
-	params := meta.Params
-	// Determine recipient (default to sender if not specified)
-	recipient := params["Recipient"]
-	if recipient == "" {
-		recipient = params["X-Recipient"]
-		if recipient == "" {
-			recipient = from
-		}
-	}
-
-	// Validate recipient address
-	_, recipient, err := utils.IDCheck(recipient)
+	// Validate the burn the same way Quote-Burn does
+	quotes, err := t.routeBurn(from, meta.Params)
 	if err != nil {
-		res.Error = schema.ErrInvalidRecipient
-		return
-	}
-
-	// Parse and validate quantity
-	qty, exists := params["Quantity"]
-	if !exists {
-		res.Error = schema.ErrMissingQuantity
-		return
-	}
-
-	amt, ok := new(big.Int).SetString(qty, 10)
-	if !ok {
-		res.Error = schema.ErrInvalidQuantityFormat
-		return
-	}
-
-	// Parse target chain
-	targetTokenId := params["TargetTokenId"]
-	if targetTokenId == "" {
-		res.Error = schema.ErrMissingTargetTokenId
-		return
-	}
-
-	_, targetTokenId, err = utils.IDCheck(targetTokenId)
-	if err != nil {
-		res.Error = schema.ErrInvalidTargetTokenId
-		return
-	}
-
-	targetChainType, ok := t.db.GetSourceTokenChain(targetTokenId)
-	if !ok {
-		res.Error = schema.ErrIncorrectTargetTokenId
-		return
-	}
-
-	// get burn fee
-	burnFee, ok := t.db.GetBurnFee(targetChainType)
-	if !ok {
-		res.Error = schema.ErrMissingBurnFee
+		res.Error = err
 		return
 	}
-
-	// Execute cross-chain burn operation
-	if err = t.crossChainBurn(from, amt, burnFee, targetChainType, targetTokenId); err != nil {
+	if err = t.checkBurnLimits(quotes); err != nil {
 		res.Error = err
 		return
 	}
 
-	// Create burn notice message
-	netBurnAmount := new(big.Int).Sub(amt, burnFee)
-	creditNotice := &vmmSchema.ResMessage{
-		Target: t.db.GetBurnProcessor(),
-		Tags: []goarSchema.Tag{
-			{Name: "Action", Value: "Burn-Notice"},
-			{Name: "Sender", Value: from},
-			{Name: "X-Recipient", Value: recipient},
-			{Name: "Quantity", Value: netBurnAmount.String()},
-			{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
-			{Name: "WrappedTokenId", Value: t.basic.DB.Info().Id},
-			{Name: "Fee", Value: burnFee.String()},
-			{Name: "FeeRecipient", Value: t.db.GetFeeRecipient()},
-			{Name: "TargetChainType", Value: targetChainType},
-			{Name: "TargetTokenId", Value: targetTokenId},
+	for i, quote := range quotes {
+		// Execute cross-chain burn operation
+		if err = t.crossChainBurn(from, quote); err != nil {
+			res.Error = err
+			return
+		}
+		targetChainType := quote.targetToken.ChainType
+		targetTokenId := quote.targetToken.TokenId
+		burnTxHash := meta.ItemId
+		if i > 0 {
+			burnTxHash = fmt.Sprintf("%s-%d", meta.ItemId, i)
+		}
 
-			{Name: "BurnTxHash", Value: meta.ItemId},
-		},
+		// Create burn notice message
+		t.db.AddBurnRecord(schema.BurnRecord{
+			BurnTxHash:      burnTxHash,
+			Sender:          from,
+			Recipient:       quote.recipient,
+			Quantity:        quote.netAmount.String(),
+			SourceQuantity:  quote.sourceAmount.String(),
+			Dust:            quote.dust.String(),
+			Fee:             quote.fee.String(),
+			TargetChainType: targetChainType,
+			TargetTokenId:   targetTokenId,
+			Timestamp:       t.basic.Now,
+			Status:          schema.BurnStatusPending,
+		})
+		creditNotice := &vmmSchema.ResMessage{
+			Target: t.db.GetBurnProcessor(),
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Burn-Notice"},
+				{Name: "Sender", Value: from},
+				{Name: "X-Recipient", Value: quote.recipient},
+				{Name: "Quantity", Value: quote.netAmount.String()},
+				{Name: "SourceQuantity", Value: quote.sourceAmount.String()},
+				{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+				{Name: "WrappedTokenId", Value: t.basic.DB.Info().Id},
+				{Name: "Fee", Value: quote.fee.String()},
+				{Name: "Dust", Value: quote.dust.String()},
+				{Name: "TargetChainType", Value: targetChainType},
+				{Name: "TargetTokenId", Value: targetTokenId},
+
+				{Name: "BurnTxHash", Value: burnTxHash},
+			},
+		}
+		if len(quotes) > 1 {
+			creditNotice.Tags = append(creditNotice.Tags,
+				goarSchema.Tag{Name: "Leg", Value: strconv.Itoa(i + 1)},
+				goarSchema.Tag{Name: "Legs", Value: strconv.Itoa(len(quotes))},
+			)
+		}
+		res.Messages = append(res.Messages, creditNotice)
 	}
+	t.db.PruneBurnRecords(t.basic.Now)
+	t.recordBurn(quotes)
+	res.Messages = append(res.Messages, t.monitorInvariants("Burn", meta.ItemId, quotes[0].targetToken.ChainType)...)
 
 	// Prepare result with cache updates
-	res.Messages = []*vmmSchema.ResMessage{creditNotice}
 	res.Cache = map[string]string{}
 	maps.Copy(res.Cache, t.cacheTokenInfo())
-	maps.Copy(res.Cache, t.basic.CacheChangeBalance(from, t.db.GetFeeRecipient()))
+	maps.Copy(res.Cache, t.basic.CacheChangeBalance(from))
 	maps.Copy(res.Cache, t.basic.CacheTotalSupply())
+	maps.Copy(res.Cache, t.cacheBurnRecords())
+	maps.Copy(res.Cache, t.cacheFees())
 	return
 }
 
-// crossChainBurn burns tokens and reduces target chain lock amounts
-func (t *Token) crossChainBurn(from string, amount *big.Int,
-	burnFee *big.Int, targetChainType, targetTokenId string) (err error) {
-	// Validate burn amount is sufficient to cover fee
-	if amount.Cmp(burnFee) < 0 {
-		err = schema.ErrIncorrectQuantity
-		return
-	}
-
-	lockAmt, ok := t.db.GetSourceLockAmount(targetTokenId, targetChainType)
-	if !ok {
-		err = schema.ErrLockAmountEmpty
-		return
-	}
-	netBurnAmount := new(big.Int).Sub(amount, burnFee)
-	if lockAmt.Cmp(netBurnAmount) < 0 {
-		err = schema.ErrInsufficientLockAmount
-		return
-	}
-
+// crossChainBurn executes a validated burn quote: it burns tokens, accrues the fee and the dust
+// in the fee ledger and reduces target chain lock amounts
+func (t *Token) crossChainBurn(from string, quote burnQuote) (err error) {
 	// Deduct full amount from sender
-	if err = t.basic.Sub(from, amount); err != nil {
+	if err = t.basic.Sub(from, quote.amount); err != nil {
 		return
 	}
 
-	// Transfer burn fee to fee recipient
-	if err = t.basic.Add(t.db.GetFeeRecipient(), burnFee); err != nil {
-		return
-	}
+	// Accrue burn fee and dust, they leave the supply until withdrawn
+	t.accrueFee(quote.targetToken.ChainType, new(big.Int).Add(quote.fee, quote.dust))
 
-	// Calculate net burn amount (total - fee) and reduce total supply
-	t.basic.DB.SetTotalSupply(new(big.Int).Sub(t.basic.DB.GetTotalSupply(), netBurnAmount))
+	// Reduce total supply by the full amount
+	t.basic.SetTotalSupply(new(big.Int).Sub(t.basic.DB.GetTotalSupply(), quote.amount))
 
 	// Reduce lock amount for the target chain
-	t.db.SetSourceLockAmount(targetTokenId, targetChainType, new(big.Int).Sub(lockAmt, netBurnAmount))
+	targetToken := quote.targetToken
+	t.db.SetSourceLockAmount(targetToken.TokenId, targetToken.ChainType, new(big.Int).Sub(quote.lockAmount, quote.sourceAmount))
 	return
 }
@@ -0,0 +1,144 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// availableFees returns the available fees of every chain type, they are backed by the locks out of the supply
+func (t *Token) availableFees() *big.Int {
+	fees := big.NewInt(0)
+	for _, ledger := range t.db.FeeLedgers() {
+		fees.Add(fees, available(ledger))
+	}
+	return fees
+}
+
+// checkInvariants returns the violated invariants after a mint or a burn of a chain type. A burn leg above
+// its lock amount is already rejected by its quote with ErrInsufficientLockAmount.
+func (t *Token) checkInvariants(chainType string) []schema.InvariantAlert {
+	alerts := make([]schema.InvariantAlert, 0)
+
+	// No lock amount is negative, the sum of the locks in wrapped units backs the supply
+	lockAmounts := t.db.GetSourceLockAmounts()
+	wrappedLocks := big.NewInt(0)
+	chainTypes := map[string]bool{chainType: true}
+	for _, sourceToken := range t.db.GetSourceTokens() {
+		chainTypes[sourceToken.ChainType] = true
+	}
+	for _, key := range slices.Sorted(maps.Keys(lockAmounts)) {
+		lockChainType, tokenId, _ := strings.Cut(key, ":")
+		chainTypes[lockChainType] = true
+		if lockAmounts[key].Sign() < 0 {
+			alerts = append(alerts, schema.InvariantAlert{
+				ChainType:     lockChainType,
+				Invariant:     schema.InvariantNegativeLock,
+				SourceTokenId: tokenId,
+				Actual:        lockAmounts[key].String(),
+				Expected:      "0",
+			})
+			continue
+		}
+		sourceToken, registered := t.db.GetSourceToken(tokenId)
+		if !registered {
+			sourceToken = schema.SourceToken{TokenId: tokenId, ChainType: lockChainType}
+		}
+		wrappedLocks.Add(wrappedLocks, t.wrappedLockAmount(sourceToken))
+	}
+
+	// Source dust stays locked and lock corrections can add reserves, so the locks may exceed the supply.
+	// The supply moves between chain types through mints and burns, a shortfall can't be assigned to
+	// one chain type and halts all of them.
+	circulating := new(big.Int).Add(t.basic.DB.GetTotalSupply(), t.availableFees())
+	if wrappedLocks.Cmp(circulating) < 0 {
+		for _, ct := range slices.Sorted(maps.Keys(chainTypes)) {
+			alerts = append(alerts, schema.InvariantAlert{
+				ChainType: ct,
+				Invariant: schema.InvariantSupplyBacking,
+				Actual:    wrappedLocks.String(),
+				Expected:  circulating.String(),
+			})
+		}
+	}
+	return alerts
+}
+
+// monitorInvariants checks the invariants after a mint or a burn, a violation halts the mints and burns
+// of its chain type and alerts the owner. Chain types already halted are not alerted again.
+func (t *Token) monitorInvariants(trigger, itemId, chainType string) []*vmmSchema.ResMessage {
+	messages := make([]*vmmSchema.ResMessage, 0)
+	for _, alert := range t.checkInvariants(chainType) {
+		if _, halted := t.db.HaltedChain(alert.ChainType); halted {
+			continue
+		}
+		alert.Trigger = trigger
+		alert.ItemId = itemId
+		alert.TotalSupply = t.basic.DB.GetTotalSupply().String()
+		alert.Fees = t.availableFees().String()
+		alert.Timestamp = t.basic.Now
+		t.db.SetHaltedChain(alert)
+
+		tags := []goarSchema.Tag{
+			{Name: "Action", Value: "Invariant-Alert"},
+			{Name: "ChainType", Value: alert.ChainType},
+			{Name: "Invariant", Value: alert.Invariant},
+			{Name: "Trigger", Value: alert.Trigger},
+			{Name: "ItemId", Value: alert.ItemId},
+			{Name: "Actual", Value: alert.Actual},
+			{Name: "Expected", Value: alert.Expected},
+			{Name: "TotalSupply", Value: alert.TotalSupply},
+			{Name: "Fees", Value: alert.Fees},
+			{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+		}
+		if alert.SourceTokenId != "" {
+			tags = append(tags, goarSchema.Tag{Name: "SourceTokenId", Value: alert.SourceTokenId})
+		}
+		messages = append(messages, &vmmSchema.ResMessage{Target: t.basic.DB.Owner(), Tags: tags})
+	}
+	return messages
+}
+
+// checkHalted rejects the mints and burns of a chain type halted by an invariant alert
+func (t *Token) checkHalted(chainType string) error {
+	if _, halted := t.db.HaltedChain(chainType); halted {
+		return schema.ErrChainHalted
+	}
+	return nil
+}
+
+// handleAcknowledgeAlert resumes a chain type halted by an invariant alert (Owner or BridgeAdmin only)
+func (t *Token) handleAcknowledgeAlert(from string, params map[string]string) (res vmmSchema.Result) {
+	if !t.isBridgeAdmin(from) {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	alert, halted := t.db.HaltedChain(params["ChainType"])
+	if !halted {
+		res.Error = schema.ErrAlertNotFound
+		return
+	}
+	t.db.DeleteHaltedChain(alert.ChainType)
+
+	alertBy, _ := json.Marshal(alert)
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   string(alertBy),
+			Tags: []goarSchema.Tag{
+				{Name: "Acknowledge-Alert-Notice", Value: "success"},
+				{Name: "ChainType", Value: alert.ChainType},
+				{Name: "Invariant", Value: alert.Invariant},
+			},
+		},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	return
+}
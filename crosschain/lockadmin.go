@@ -0,0 +1,212 @@
+package crosschain
+
+import (
+	"maps"
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// parseLockCorrection validates the bridge admin and the Reason and Reference params of a lock amount correction
+func (t *Token) parseLockCorrection(from string, params map[string]string) (reason, reference string, err error) {
+	if !t.isBridgeAdmin(from) {
+		return "", "", schema.ErrIncorrectOwner
+	}
+	if reason = params["Reason"]; reason == "" {
+		return "", "", schema.ErrMissingReason
+	}
+	if reference = params["Reference"]; reference == "" {
+		return "", "", schema.ErrMissingReference
+	}
+	return
+}
+
+// registeredSourceToken returns the registered source token of a param
+func (t *Token) registeredSourceToken(params map[string]string, param string) (schema.SourceToken, error) {
+	if params[param] == "" {
+		return schema.SourceToken{}, schema.ErrMissingSourceTokenId
+	}
+	_, tokenId, err := utils.IDCheck(params[param])
+	if err != nil {
+		return schema.SourceToken{}, schema.ErrInvalidSourceTokenId
+	}
+	sourceToken, registered := t.db.GetSourceToken(tokenId)
+	if !registered {
+		return sourceToken, schema.ErrUnregisteredSourceToken
+	}
+	return sourceToken, nil
+}
+
+// lockCorrectionResult notifies the BurnProcessor and the owner of a lock amount correction
+func (t *Token) lockCorrectionResult(record schema.LockAuditRecord) (res vmmSchema.Result) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: record.Action + "-Notice"},
+		{Name: "Operator", Value: record.Operator},
+		{Name: "SourceTokenId", Value: record.SourceTokenId},
+		{Name: "SourceChainType", Value: record.ChainType},
+		{Name: "PreviousAmount", Value: record.PreviousAmount},
+		{Name: "LockAmount", Value: record.Amount},
+		{Name: "Reason", Value: record.Reason},
+		{Name: "Reference", Value: record.Reference},
+		{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+	}
+	if record.ToSourceTokenId != "" {
+		tags = append(tags,
+			goarSchema.Tag{Name: "ToSourceTokenId", Value: record.ToSourceTokenId},
+			goarSchema.Tag{Name: "ToSourceChainType", Value: record.ToChainType},
+			goarSchema.Tag{Name: "ToPreviousAmount", Value: record.ToPrevious},
+			goarSchema.Tag{Name: "ToLockAmount", Value: record.ToAmount},
+		)
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{Target: t.db.GetBurnProcessor(), Tags: tags},
+		{Target: t.basic.DB.Owner(), Tags: tags},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	return
+}
+
+// handleAdjustLockAmount sets the lock amount of a source token to the observed reserve,
+// e.g. after an incident (Owner or BridgeAdmin only)
+func (t *Token) handleAdjustLockAmount(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	reason, reference, err := t.parseLockCorrection(from, meta.Params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	sourceToken, err := t.registeredSourceToken(meta.Params, "SourceTokenId")
+	if err != nil {
+		res.Error = err
+		return
+	}
+	lockAmount, ok := new(big.Int).SetString(meta.Params["LockAmount"], 10)
+	if !ok || lockAmount.Sign() < 0 {
+		res.Error = schema.ErrInvalidQuantityFormat
+		return
+	}
+
+	previous, _ := t.db.GetSourceLockAmount(sourceToken.TokenId, sourceToken.ChainType)
+	if previous == nil {
+		previous = big.NewInt(0)
+	}
+	t.db.SetSourceLockAmount(sourceToken.TokenId, sourceToken.ChainType, lockAmount)
+	record := schema.LockAuditRecord{
+		Action:         "Adjust-Lock-Amount",
+		Operator:       from,
+		SourceTokenId:  sourceToken.TokenId,
+		ChainType:      sourceToken.ChainType,
+		PreviousAmount: previous.String(),
+		Amount:         lockAmount.String(),
+		Reason:         reason,
+		Reference:      reference,
+		ItemId:         meta.ItemId,
+		Timestamp:      t.basic.Now,
+	}
+	t.db.AppendLockAuditRecord(record)
+
+	// A lock amount below the supply halts the chain types before the next mint or burn
+	alerts := t.monitorInvariants("Adjust-Lock-Amount", meta.ItemId, sourceToken.ChainType)
+	res = t.lockCorrectionResult(record)
+	res.Messages = append(res.Messages, alerts...)
+	return
+}
+
+// handleMoveLockAmount moves a lock amount between source tokens, e.g. for a token migration
+// (Owner or BridgeAdmin only). Quantity is in source units of SourceTokenId and must convert exactly.
+func (t *Token) handleMoveLockAmount(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	reason, reference, err := t.parseLockCorrection(from, meta.Params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	sourceToken, err := t.registeredSourceToken(meta.Params, "SourceTokenId")
+	if err != nil {
+		res.Error = err
+		return
+	}
+	toToken, err := t.registeredSourceToken(meta.Params, "ToSourceTokenId")
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if toToken.TokenId == sourceToken.TokenId {
+		res.Error = schema.ErrInvalidSourceToken
+		return
+	}
+	quantity, ok := new(big.Int).SetString(meta.Params["Quantity"], 10)
+	if !ok || quantity.Sign() <= 0 {
+		res.Error = schema.ErrInvalidQuantityFormat
+		return
+	}
+
+	// Convert through the wrapped units, a move can't create dust
+	fromShift, err := t.decimalsShift(sourceToken)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	toShift, err := t.decimalsShift(toToken)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	wrapped, dust := toWrapped(quantity, fromShift)
+	toQuantity, toDust := toSource(wrapped, toShift)
+	if dust.Sign() > 0 || toDust.Sign() > 0 {
+		res.Error = schema.ErrDustAmount
+		return
+	}
+
+	previous, ok := t.db.GetSourceLockAmount(sourceToken.TokenId, sourceToken.ChainType)
+	if !ok || previous.Cmp(quantity) < 0 {
+		res.Error = schema.ErrInsufficientLockAmount
+		return
+	}
+	toPrevious, _ := t.db.GetSourceLockAmount(toToken.TokenId, toToken.ChainType)
+	if toPrevious == nil {
+		toPrevious = big.NewInt(0)
+	}
+	lockAmount := new(big.Int).Sub(previous, quantity)
+	toLockAmount := new(big.Int).Add(toPrevious, toQuantity)
+	t.db.SetSourceLockAmount(sourceToken.TokenId, sourceToken.ChainType, lockAmount)
+	t.db.SetSourceLockAmount(toToken.TokenId, toToken.ChainType, toLockAmount)
+
+	record := schema.LockAuditRecord{
+		Action:          "Move-Lock-Amount",
+		Operator:        from,
+		SourceTokenId:   sourceToken.TokenId,
+		ChainType:       sourceToken.ChainType,
+		PreviousAmount:  previous.String(),
+		Amount:          lockAmount.String(),
+		ToSourceTokenId: toToken.TokenId,
+		ToChainType:     toToken.ChainType,
+		ToPrevious:      toPrevious.String(),
+		ToAmount:        toLockAmount.String(),
+		Reason:          reason,
+		Reference:       reference,
+		ItemId:          meta.ItemId,
+		Timestamp:       t.basic.Now,
+	}
+	t.db.AppendLockAuditRecord(record)
+
+	alerts := t.monitorInvariants("Move-Lock-Amount", meta.ItemId, sourceToken.ChainType)
+	res = t.lockCorrectionResult(record)
+	res.Messages = append(res.Messages, alerts...)
+	return
+}
+
+// handleLockAuditLog returns a page of the lock amount audit log in log order as JSON
+func (t *Token) handleLockAuditLog(from string, params map[string]string) (res vmmSchema.Result) {
+	page, next, err := paginate(t.db.LockAuditRecords(), func(record schema.LockAuditRecord) string {
+		return record.ItemId
+	}, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	return t.recordsResult(from, "Lock-Audit-Log", page, next, params)
+}
@@ -0,0 +1,99 @@
+package crosschain
+
+import (
+	"maps"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// parseMintRetention validates the MintRecordMaxAge and MintRecordMaxBlocks params,
+// an empty param keeps the given current value. Hashes pruned by age are only compacted
+// by the block watermark, so MaxAge needs MaxBlocks.
+func parseMintRetention(maxAgeStr, maxBlocksStr string, retention schema.MintRetention) (schema.MintRetention, error) {
+	var err error
+	if maxAgeStr != "" {
+		if retention.MaxAge, err = strconv.ParseInt(maxAgeStr, 10, 64); err != nil || retention.MaxAge < 0 {
+			return schema.MintRetention{}, schema.ErrInvalidMintRetention
+		}
+	}
+	if maxBlocksStr != "" {
+		if retention.MaxBlocks, err = strconv.ParseUint(maxBlocksStr, 10, 64); err != nil {
+			return schema.MintRetention{}, schema.ErrInvalidMintRetention
+		}
+	}
+	if retention.MaxAge > 0 && retention.MaxBlocks == 0 {
+		return schema.MintRetention{}, schema.ErrInvalidMintRetention
+	}
+	return retention, nil
+}
+
+// checkMintReplay rejects a mint tx hash that was already minted, or that is too old
+// to be checked against the retained records
+func (t *Token) checkMintReplay(mintTxHash, sourceChainType string, params map[string]string) (blockHeight uint64, err error) {
+	if t.minted(mintTxHash) {
+		return 0, schema.ErrRepeatMint
+	}
+
+	// Block height retention needs the source chain block height of every mint
+	blockHeightStr := params["X-BlockHeight"]
+	if blockHeightStr == "" {
+		if t.db.MintRetention().MaxBlocks > 0 {
+			return 0, schema.ErrMissingBlockHeight
+		}
+		return 0, nil
+	}
+	blockHeight, err = strconv.ParseUint(blockHeightStr, 10, 64)
+	if err != nil || blockHeight == 0 {
+		return 0, schema.ErrInvalidBlockHeight
+	}
+	if blockHeight <= t.db.MintWatermark(sourceChainType) {
+		return 0, schema.ErrMintBelowRetention
+	}
+	return blockHeight, nil
+}
+
+// minted reports whether a mint tx hash was already minted, including the records pruned by age
+func (t *Token) minted(mintTxHash string) bool {
+	if _, ok := t.db.GetMintedRecord(mintTxHash); ok {
+		return true
+	}
+	return t.db.MintArchived(mintTxHash)
+}
+
+// handleResetMintWatermark lowers the highest seen block height and the watermark of ChainType to
+// BlockHeight, e.g. after a mistyped X-BlockHeight rejects every later mint (Owner or BridgeAdmin only)
+func (t *Token) handleResetMintWatermark(from string, params map[string]string) (res vmmSchema.Result) {
+	if !t.isBridgeAdmin(from) {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	chainType := params["ChainType"]
+	if chainType == "" {
+		res.Error = schema.ErrMissingSourceChain
+		return
+	}
+	blockHeight, err := strconv.ParseUint(params["BlockHeight"], 10, 64)
+	if err != nil {
+		res.Error = schema.ErrInvalidBlockHeight
+		return
+	}
+	t.db.ResetMintWatermark(chainType, blockHeight)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Reset-Mint-Watermark-Notice", Value: "success"},
+				{Name: "ChainType", Value: chainType},
+				{Name: "BlockHeight", Value: strconv.FormatUint(blockHeight, 10)},
+				{Name: "Watermark", Value: strconv.FormatUint(t.db.MintWatermark(chainType), 10)},
+			},
+		},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheMintRecords())
+	return
+}
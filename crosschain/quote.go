@@ -0,0 +1,231 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// burnQuote is a cross-chain burn computed without changing state, the fields resolved
+// before a validation error are kept
+type burnQuote struct {
+	recipient    string
+	targetToken  schema.SourceToken
+	amount       *big.Int
+	fee          *big.Int
+	netAmount    *big.Int // released on the target chain, in wrapped units
+	sourceAmount *big.Int // released on the target chain, in source units
+	dust         *big.Int
+	lockAmount   *big.Int
+}
+
+// burnLeg is a routed burn of a single source token as returned by Quote-Burn
+type burnLeg struct {
+	TargetTokenId  string
+	Quantity       string
+	Fee            string
+	NetQuantity    string
+	SourceQuantity string
+	Dust           string
+}
+
+// parseBurnParams returns the recipient and validates the quantity of a cross-chain burn,
+// the recipient is validated against the target chain type
+func parseBurnParams(from string, params map[string]string) (recipient string, amount *big.Int, err error) {
+	// Determine recipient (default to sender if not specified)
+	recipient = params["Recipient"]
+	if recipient == "" {
+		recipient = params["X-Recipient"]
+		if recipient == "" {
+			recipient = from
+		}
+	}
+
+	// Parse and validate quantity
+	qty, exists := params["Quantity"]
+	if !exists {
+		err = schema.ErrMissingQuantity
+		return
+	}
+	amount, ok := new(big.Int).SetString(qty, 10)
+	if !ok {
+		err = schema.ErrInvalidQuantityFormat
+		return
+	}
+	return
+}
+
+// quoteBurn validates a cross-chain burn of from to the TargetTokenId param and computes its amounts
+func (t *Token) quoteBurn(from string, params map[string]string) (quote burnQuote, err error) {
+	if quote.recipient, quote.amount, err = parseBurnParams(from, params); err != nil {
+		return
+	}
+
+	// Parse target chain
+	targetTokenId := params["TargetTokenId"]
+	if targetTokenId == "" {
+		err = schema.ErrMissingTargetTokenId
+		return
+	}
+	if _, targetTokenId, err = utils.IDCheck(targetTokenId); err != nil {
+		err = schema.ErrInvalidTargetTokenId
+		return
+	}
+	targetToken, ok := t.db.GetSourceToken(targetTokenId)
+	if !ok {
+		err = schema.ErrIncorrectTargetTokenId
+		return
+	}
+	if chainType := params["TargetChainType"]; chainType != "" && chainType != targetToken.ChainType {
+		err = schema.ErrIncorrectTargetTokenId
+		return
+	}
+	if quote.recipient, err = parseRecipient(targetToken.ChainType, quote.recipient); err != nil {
+		return
+	}
+	return t.quoteTokenBurn(from, quote.recipient, quote.amount, targetToken)
+}
+
+// quoteTokenBurn quotes a burn to a target token with the burn fee of the token
+func (t *Token) quoteTokenBurn(from, recipient string, amount *big.Int, targetToken schema.SourceToken) (quote burnQuote, err error) {
+	quote = burnQuote{recipient: recipient, targetToken: targetToken, amount: amount}
+
+	// get the burn fee of the amount
+	if quote.fee, err = t.burnFee(targetToken, amount); err != nil {
+		return
+	}
+	return t.quoteLeg(from, quote)
+}
+
+// quoteLeg validates the burn of the amount and the fee of a quote, and computes the released amounts
+func (t *Token) quoteLeg(from string, quote burnQuote) (burnQuote, error) {
+	// Frozen accounts can't burn
+	if t.basic.DB.IsFrozen(from) {
+		return quote, schema.ErrAccountFrozen
+	}
+	if err := t.checkHalted(quote.targetToken.ChainType); err != nil {
+		return quote, err
+	}
+
+	// Validate burn amount is sufficient to cover fee
+	if quote.amount.Cmp(quote.fee) < 0 {
+		return quote, schema.ErrIncorrectQuantity
+	}
+
+	// Convert the net amount into source units
+	shift, err := t.decimalsShift(quote.targetToken)
+	if err != nil {
+		return quote, err
+	}
+	quote.netAmount = new(big.Int).Sub(quote.amount, quote.fee)
+	quote.sourceAmount, quote.dust = toSource(quote.netAmount, shift)
+	quote.netAmount.Sub(quote.netAmount, quote.dust)
+	if err = t.checkDust(quote.dust); err != nil {
+		return quote, err
+	}
+
+	lockAmount, ok := t.db.GetSourceLockAmount(quote.targetToken.TokenId, quote.targetToken.ChainType)
+	if !ok {
+		return quote, schema.ErrLockAmountEmpty
+	}
+	quote.lockAmount = lockAmount
+	if lockAmount.Cmp(quote.sourceAmount) < 0 {
+		return quote, schema.ErrInsufficientLockAmount
+	}
+	return quote, t.checkSpendable(from, quote.amount)
+}
+
+// checkSpendable checks the balance of from covers an amount, held amounts are not spendable
+func (t *Token) checkSpendable(from string, amount *big.Int) error {
+	balance, err := t.basic.DB.BalanceOf(from)
+	if err != nil {
+		return err
+	}
+	if amount.Sign() > 0 && new(big.Int).Sub(balance, t.basic.HeldOf(from)).Cmp(amount) < 0 {
+		return schema.ErrInsufficientBalance
+	}
+	return nil
+}
+
+// handleQuoteBurn previews a cross-chain burn of the sender: the resolved target chain, the fee,
+// the net amount and the lock amount, and the error the burn would fail with.
+// A burn split across several source tokens also returns its legs as JSON.
+func (t *Token) handleQuoteBurn(from string, params map[string]string) (res vmmSchema.Result) {
+	quotes, err := t.routeBurn(from, params)
+	if err == nil {
+		err = t.checkBurnLimits(quotes)
+	}
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Quote-Burn"},
+		{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+		{Name: "Ok", Value: strconv.FormatBool(err == nil)},
+	}
+	if err != nil {
+		tags = append(tags, goarSchema.Tag{Name: "Error", Value: err.Error()})
+	}
+
+	data := ""
+	quote := burnQuote{}
+	if len(quotes) == 1 {
+		quote = quotes[0]
+	} else if len(quotes) > 1 {
+		// Split burns report the totals of their legs, source amounts are per leg
+		quote = burnQuote{targetToken: schema.SourceToken{ChainType: quotes[0].targetToken.ChainType}}
+		quote.amount, quote.fee, quote.netAmount, quote.dust = big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)
+		legs := make([]burnLeg, 0, len(quotes))
+		for _, leg := range quotes {
+			quote.amount.Add(quote.amount, leg.amount)
+			quote.fee.Add(quote.fee, leg.fee)
+			quote.netAmount.Add(quote.netAmount, leg.netAmount)
+			quote.dust.Add(quote.dust, leg.dust)
+			legs = append(legs, burnLeg{
+				TargetTokenId:  leg.targetToken.TokenId,
+				Quantity:       leg.amount.String(),
+				Fee:            leg.fee.String(),
+				NetQuantity:    leg.netAmount.String(),
+				SourceQuantity: leg.sourceAmount.String(),
+				Dust:           leg.dust.String(),
+			})
+		}
+		legsBy, _ := json.Marshal(legs)
+		data = string(legsBy)
+		tags = append(tags, goarSchema.Tag{Name: "Legs", Value: strconv.Itoa(len(legs))})
+	}
+
+	if quote.targetToken.ChainType != "" {
+		tags = append(tags, goarSchema.Tag{Name: "TargetChainType", Value: quote.targetToken.ChainType})
+	}
+	if quote.targetToken.TokenId != "" {
+		tags = append(tags, goarSchema.Tag{Name: "TargetTokenId", Value: quote.targetToken.TokenId})
+	}
+	amounts := []struct {
+		name   string
+		amount *big.Int
+	}{
+		{"Quantity", quote.amount},
+		{"Fee", quote.fee},
+		{"NetQuantity", quote.netAmount},
+		{"SourceQuantity", quote.sourceAmount},
+		{"Dust", quote.dust},
+		{"LockAmount", quote.lockAmount},
+	}
+	for _, a := range amounts {
+		if a.amount != nil {
+			tags = append(tags, goarSchema.Tag{Name: a.name, Value: a.amount.String()})
+		}
+	}
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   data,
+			Tags:   tags,
+		},
+	}
+	return
+}
@@ -0,0 +1,131 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+const (
+	// DefaultRecordsLimit is the page size of a records listing without Limit
+	DefaultRecordsLimit = 100
+	// MaxRecordsLimit bounds the page size of a records listing
+	MaxRecordsLimit = 1000
+	// RecentRecordsCacheSize is the number of records kept in the mint-records and burn-records cache keys
+	RecentRecordsCacheSize = 20
+)
+
+// parseBurnRecordMaxAge validates the BurnRecordMaxAge param (UnixMilli), 0 keeps the resolved records
+func parseBurnRecordMaxAge(maxAgeStr string) (int64, error) {
+	maxAge, err := strconv.ParseInt(maxAgeStr, 10, 64)
+	if err != nil || maxAge < 0 {
+		return 0, schema.ErrInvalidBurnRetention
+	}
+	return maxAge, nil
+}
+
+// recentRecords returns the last records, newest first
+func recentRecords[T any](records []T) []T {
+	recent := slices.Clone(records[max(len(records)-RecentRecordsCacheSize, 0):])
+	slices.Reverse(recent)
+	return recent
+}
+
+// paginate returns the page of records following the Cursor param, the hash of the last record
+// of the previous page, and the cursor of the next page, empty on the last page
+func paginate[T any](records []T, hash func(T) string, params map[string]string) (page []T, next string, err error) {
+	limit := DefaultRecordsLimit
+	if params["Limit"] != "" {
+		limit, err = strconv.Atoi(params["Limit"])
+		if err != nil || limit <= 0 || limit > MaxRecordsLimit {
+			return nil, "", schema.ErrInvalidPagination
+		}
+	}
+
+	start := 0
+	if cursor := params["Cursor"]; cursor != "" {
+		i := slices.IndexFunc(records, func(record T) bool { return hash(record) == cursor })
+		if i < 0 {
+			// The cursor record was pruned or belongs to another chain
+			return nil, "", schema.ErrInvalidPagination
+		}
+		start = i + 1
+	}
+
+	end := min(start+limit, len(records))
+	page = records[start:end]
+	if end < len(records) {
+		next = hash(records[end-1])
+	}
+	return page, next, nil
+}
+
+func (t *Token) recordsResult(from, action string, page any, next string, params map[string]string) (res vmmSchema.Result) {
+	pageBy, _ := json.Marshal(page)
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: action},
+		{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+	}
+	if chainType := params["ChainType"]; chainType != "" {
+		tags = append(tags, goarSchema.Tag{Name: "ChainType", Value: chainType})
+	}
+	if next != "" {
+		tags = append(tags, goarSchema.Tag{Name: "Next-Cursor", Value: next})
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Data:   string(pageBy),
+			Tags:   tags,
+		},
+	}
+	return
+}
+
+// handleMintRecord returns the record of a mint by its X-MintTxHash, in any form valid for ChainType
+func (t *Token) handleMintRecord(from string, params map[string]string) (res vmmSchema.Result) {
+	record, ok := t.db.GetMintedRecord(canonicalMintTxHash(params["ChainType"], params["MintTxHash"]))
+	if !ok {
+		res.Error = schema.ErrMintRecordNotFound
+		return
+	}
+	return t.recordsResult(from, "Mint-Record", record, "", nil)
+}
+
+// handleMintRecords lists the mint records in mint order, optionally filtered by ChainType
+func (t *Token) handleMintRecords(from string, params map[string]string) (res vmmSchema.Result) {
+	page, next, err := paginate(t.db.MintRecords(params["ChainType"]), func(record schema.MintRecord) string {
+		return record.MintTxHash
+	}, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	return t.recordsResult(from, "Mint-Records", page, next, params)
+}
+
+// handleBurnRecord returns the record of a burn by its BurnTxHash
+func (t *Token) handleBurnRecord(from string, params map[string]string) (res vmmSchema.Result) {
+	record, ok := t.db.GetBurnRecord(params["BurnTxHash"])
+	if !ok {
+		res.Error = schema.ErrBurnRecordNotFound
+		return
+	}
+	return t.recordsResult(from, "Burn-Record", record, "", nil)
+}
+
+// handleBurnRecords lists the burn records in burn order, optionally filtered by ChainType
+func (t *Token) handleBurnRecords(from string, params map[string]string) (res vmmSchema.Result) {
+	page, next, err := paginate(t.db.BurnRecords(params["ChainType"]), func(record schema.BurnRecord) string {
+		return record.BurnTxHash
+	}, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	return t.recordsResult(from, "Burn-Records", page, next, params)
+}
@@ -0,0 +1,202 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// reserveStatus is the latest reserve report of a source token with its current lock amount,
+// as returned by the Reserves query
+type reserveStatus struct {
+	schema.ReserveReport
+	LockAmount    string
+	CollateralBps string // reserve over lock amount in basis points, empty without a lock amount
+}
+
+// parseReserveAttestors validates the ReserveAttestors JSON array, an empty array disables the reports
+func parseReserveAttestors(attestorsStr string) ([]string, error) {
+	var attestors []string
+	if err := json.Unmarshal([]byte(attestorsStr), &attestors); err != nil {
+		return nil, schema.ErrInvalidReserveAttestors
+	}
+	var err error
+	for i, attestor := range attestors {
+		if _, attestors[i], err = utils.IDCheck(attestor); err != nil {
+			return nil, schema.ErrInvalidReserveAttestors
+		}
+		if slices.Contains(attestors[:i], attestors[i]) {
+			return nil, schema.ErrInvalidReserveAttestors
+		}
+	}
+	return attestors, nil
+}
+
+// parseReserveTolerance validates the ReserveTolerance param in basis points of the lock amount
+func parseReserveTolerance(toleranceStr string) (int64, error) {
+	tolerance, err := strconv.ParseInt(toleranceStr, 10, 64)
+	if err != nil || tolerance < 0 || tolerance > MaxFeeBps {
+		return 0, schema.ErrInvalidReserveTolerance
+	}
+	return tolerance, nil
+}
+
+// reserveShortfall reports whether a reserve is below the lock amount by more than the tolerance
+func reserveShortfall(reserve, lockAmount *big.Int, tolerance int64) bool {
+	reserveBps := new(big.Int).Mul(reserve, big.NewInt(MaxFeeBps))
+	minBps := new(big.Int).Mul(lockAmount, big.NewInt(MaxFeeBps-tolerance))
+	return reserveBps.Cmp(minBps) < 0
+}
+
+// collateralBps returns the reserve over the lock amount in basis points, empty without a lock amount
+func collateralBps(reserve, lockAmount *big.Int) string {
+	if lockAmount.Sign() == 0 {
+		return ""
+	}
+	ratio := new(big.Int).Mul(reserve, big.NewInt(MaxFeeBps))
+	return ratio.Quo(ratio, lockAmount).String()
+}
+
+// lockAmountOf returns the lock amount of a source token, zero without one
+func (t *Token) lockAmountOf(sourceToken schema.SourceToken) *big.Int {
+	lockAmount, ok := t.db.GetSourceLockAmount(sourceToken.TokenId, sourceToken.ChainType)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return lockAmount
+}
+
+// reserveStatuses returns the latest reserve reports of a chain type, every chain type if empty,
+// ordered by source token
+func (t *Token) reserveStatuses(chainType string) []reserveStatus {
+	reports := t.db.ReserveReports()
+	statuses := make([]reserveStatus, 0, len(reports))
+	for _, report := range reports {
+		if chainType != "" && report.ChainType != chainType {
+			continue
+		}
+		reserve, _ := new(big.Int).SetString(report.Reserve, 10)
+		lockAmount := t.lockAmountOf(schema.SourceToken{TokenId: report.SourceTokenId, ChainType: report.ChainType})
+		statuses = append(statuses, reserveStatus{
+			ReserveReport: report,
+			LockAmount:    lockAmount.String(),
+			CollateralBps: collateralBps(reserve, lockAmount),
+		})
+	}
+	slices.SortFunc(statuses, func(a, b reserveStatus) int {
+		return strings.Compare(a.SourceTokenId, b.SourceTokenId)
+	})
+	return statuses
+}
+
+// handleReserveReport records the reserve observed by an attestor for a source token. A reserve below
+// the lock amount by more than the tolerance pauses the mints of the source token, until a later
+// report is within the tolerance.
+func (t *Token) handleReserveReport(from string, params map[string]string) (res vmmSchema.Result) {
+	_, from, err := utils.IDCheck(from)
+	if err != nil {
+		res.Error = schema.ErrInvalidFrom
+		return
+	}
+	if !slices.Contains(t.db.ReserveAttestors(), from) {
+		res.Error = schema.ErrIncorrectReserveAttestor
+		return
+	}
+
+	sourceToken, err := t.registeredSourceToken(params, "SourceTokenId")
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if params["SourceChainType"] == "" {
+		res.Error = schema.ErrMissingSourceChain
+		return
+	}
+	if params["SourceChainType"] != sourceToken.ChainType {
+		res.Error = schema.ErrIncorrectSourceChainType
+		return
+	}
+	reserve, ok := new(big.Int).SetString(params["Reserve"], 10)
+	if !ok || reserve.Sign() < 0 {
+		res.Error = schema.ErrInvalidReserveReport
+		return
+	}
+	blockHeight, err := strconv.ParseUint(params["BlockHeight"], 10, 64)
+	if err != nil {
+		res.Error = schema.ErrInvalidReserveReport
+		return
+	}
+	timestamp, err := strconv.ParseInt(params["Timestamp"], 10, 64)
+	if err != nil || timestamp < 0 {
+		res.Error = schema.ErrInvalidReserveReport
+		return
+	}
+
+	// Only a report of a later block replaces the latest one
+	previous, exists := t.db.ReserveReport(sourceToken.TokenId)
+	if exists && blockHeight <= previous.BlockHeight {
+		res.Error = schema.ErrStaleReserveReport
+		return
+	}
+
+	lockAmount := t.lockAmountOf(sourceToken)
+	report := schema.ReserveReport{
+		SourceTokenId: sourceToken.TokenId,
+		ChainType:     sourceToken.ChainType,
+		Reserve:       reserve.String(),
+		BlockHeight:   blockHeight,
+		Timestamp:     timestamp,
+		Attestor:      from,
+		ReportedAt:    t.basic.Now,
+		MintPaused:    reserveShortfall(reserve, lockAmount, t.db.ReserveTolerance()),
+	}
+	t.db.SetReserveReport(report)
+
+	tags := []goarSchema.Tag{
+		{Name: "SourceTokenId", Value: report.SourceTokenId},
+		{Name: "SourceChainType", Value: report.ChainType},
+		{Name: "Reserve", Value: report.Reserve},
+		{Name: "LockAmount", Value: lockAmount.String()},
+		{Name: "CollateralBps", Value: collateralBps(reserve, lockAmount)},
+		{Name: "BlockHeight", Value: strconv.FormatUint(report.BlockHeight, 10)},
+		{Name: "Timestamp", Value: strconv.FormatInt(report.Timestamp, 10)},
+		{Name: "MintPaused", Value: strconv.FormatBool(report.MintPaused)},
+		{Name: "Ticker", Value: t.basic.DB.Info().Ticker},
+	}
+	res.Messages = []*vmmSchema.ResMessage{
+		{Target: from, Tags: append([]goarSchema.Tag{{Name: "Action", Value: "Reserve-Report-Notice"}}, tags...)},
+	}
+
+	// Alert the owner and the BurnProcessor when the mints pause or resume
+	if report.MintPaused != previous.MintPaused {
+		action := "Reserve-Shortfall-Alert"
+		if !report.MintPaused {
+			action = "Reserve-Restored-Notice"
+		}
+		alertTags := append([]goarSchema.Tag{
+			{Name: "Action", Value: action},
+			{Name: "Attestor", Value: from},
+		}, tags...)
+		res.Messages = append(res.Messages,
+			&vmmSchema.ResMessage{Target: t.basic.DB.Owner(), Tags: alertTags},
+			&vmmSchema.ResMessage{Target: t.db.GetBurnProcessor(), Tags: alertTags},
+		)
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	return
+}
+
+// handleReserves returns the latest reserve reports with the collateralization of their source tokens as JSON,
+// optionally of a ChainType
+func (t *Token) handleReserves(from string, params map[string]string) (res vmmSchema.Result) {
+	return t.recordsResult(from, "Reserves", t.reserveStatuses(params["ChainType"]), "", params)
+}
@@ -0,0 +1,178 @@
+package crosschain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+)
+
+// parseBurnRoutes strictly decodes the burn routes keyed by chain type, a null route removes the key
+func parseBurnRoutes(routesStr string) (map[string]*schema.BurnRoute, error) {
+	routes := make(map[string]*schema.BurnRoute)
+	decoder := json.NewDecoder(bytes.NewReader([]byte(routesStr)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&routes); err != nil {
+		return nil, schema.ErrInvalidBurnRoute
+	}
+	var err error
+	for chainType, route := range routes {
+		if chainType == "" {
+			return nil, schema.ErrInvalidBurnRoute
+		}
+		if route == nil {
+			continue
+		}
+		switch route.Strategy {
+		case schema.BurnRouteLargest, schema.BurnRoutePreferred, schema.BurnRouteSplit:
+		default:
+			return nil, schema.ErrInvalidBurnRoute
+		}
+		for i, tokenId := range route.Preferred {
+			if _, route.Preferred[i], err = utils.IDCheck(tokenId); err != nil {
+				return nil, schema.ErrInvalidBurnRoute
+			}
+		}
+	}
+	return routes, nil
+}
+
+// wrappedLockAmount returns the lock amount of a source token in wrapped units
+func (t *Token) wrappedLockAmount(sourceToken schema.SourceToken) *big.Int {
+	lockAmount, _ := t.db.GetSourceLockAmount(sourceToken.TokenId, sourceToken.ChainType)
+	shift, err := t.decimalsShift(sourceToken)
+	if lockAmount == nil || err != nil {
+		return big.NewInt(0)
+	}
+	amount, _ := toWrapped(lockAmount, shift)
+	return amount
+}
+
+// routeCandidates returns the source tokens of a chain type in routing order: the preferred tokens
+// in the configured order, then the others by descending lock amount
+func (t *Token) routeCandidates(chainType string, route schema.BurnRoute) []schema.SourceToken {
+	candidates := make([]schema.SourceToken, 0)
+	for _, sourceToken := range t.db.GetSourceTokens() {
+		if sourceToken.ChainType == chainType {
+			candidates = append(candidates, sourceToken)
+		}
+	}
+	locks := make(map[string]*big.Int, len(candidates))
+	for _, sourceToken := range candidates {
+		locks[sourceToken.TokenId] = t.wrappedLockAmount(sourceToken)
+	}
+
+	rank := func(tokenId string) int {
+		if route.Strategy != schema.BurnRoutePreferred {
+			return len(route.Preferred)
+		}
+		if i := slices.Index(route.Preferred, tokenId); i >= 0 {
+			return i
+		}
+		return len(route.Preferred)
+	}
+	slices.SortFunc(candidates, func(a, b schema.SourceToken) int {
+		if ra, rb := rank(a.TokenId), rank(b.TokenId); ra != rb {
+			return ra - rb
+		}
+		if c := locks[b.TokenId].Cmp(locks[a.TokenId]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.TokenId, b.TokenId)
+	})
+	return candidates
+}
+
+// routeBurn quotes a cross-chain burn to the TargetTokenId param, else to a source token of the
+// TargetChainType param picked by the burn route of the chain type
+func (t *Token) routeBurn(from string, params map[string]string) ([]burnQuote, error) {
+	chainType := params["TargetChainType"]
+	if params["TargetTokenId"] != "" || chainType == "" {
+		quote, err := t.quoteBurn(from, params)
+		return []burnQuote{quote}, err
+	}
+
+	recipient, amount, err := parseBurnParams(from, params)
+	if err != nil {
+		return nil, err
+	}
+	if recipient, err = parseRecipient(chainType, recipient); err != nil {
+		return nil, err
+	}
+	route, ok := t.db.BurnRoute(chainType)
+	if !ok {
+		route.Strategy = schema.BurnRouteLargest
+	}
+	candidates := t.routeCandidates(chainType, route)
+	if len(candidates) == 0 {
+		return nil, schema.ErrNoBurnRoute
+	}
+	if route.Strategy == schema.BurnRouteSplit {
+		return t.splitBurn(from, recipient, amount, chainType, candidates)
+	}
+
+	// The first source token with enough lock amount takes the whole burn
+	var quote burnQuote
+	for _, sourceToken := range candidates {
+		quote, err = t.quoteTokenBurn(from, recipient, amount, sourceToken)
+		if !errors.Is(err, schema.ErrInsufficientLockAmount) && !errors.Is(err, schema.ErrLockAmountEmpty) {
+			break
+		}
+	}
+	return []burnQuote{quote}, err
+}
+
+// splitBurn splits a burn across the source tokens of a chain type by descending lock amount, one leg
+// per source token. The burn fee of the chain type is computed on the whole amount and paid by the first leg,
+// the fee models of the source tokens don't apply as the legs are not known before the fee.
+func (t *Token) splitBurn(from, recipient string, amount *big.Int, chainType string, candidates []schema.SourceToken) ([]burnQuote, error) {
+	fee, err := t.burnFee(schema.SourceToken{ChainType: chainType}, amount)
+	if err != nil {
+		return nil, err
+	}
+	if t.basic.DB.IsFrozen(from) {
+		return nil, schema.ErrAccountFrozen
+	}
+	if amount.Cmp(fee) < 0 {
+		return nil, schema.ErrIncorrectQuantity
+	}
+	// The legs are executed one after the other, the whole amount must be spendable
+	if err = t.checkSpendable(from, amount); err != nil {
+		return nil, err
+	}
+
+	quotes := make([]burnQuote, 0, len(candidates))
+	remaining := new(big.Int).Sub(amount, fee)
+	for _, sourceToken := range candidates {
+		if remaining.Sign() == 0 && len(quotes) > 0 {
+			break
+		}
+		take := t.wrappedLockAmount(sourceToken)
+		if take.Cmp(remaining) > 0 {
+			take.Set(remaining)
+		}
+		// A burn of only the fee still has a first leg paying it
+		if take.Sign() == 0 && (remaining.Sign() > 0 || len(quotes) > 0) {
+			continue
+		}
+		quote := burnQuote{recipient: recipient, targetToken: sourceToken, amount: take, fee: big.NewInt(0)}
+		if len(quotes) == 0 {
+			quote.amount = new(big.Int).Add(take, fee)
+			quote.fee = fee
+		}
+		if quote, err = t.quoteLeg(from, quote); err != nil {
+			return append(quotes, quote), err
+		}
+		quotes = append(quotes, quote)
+		remaining.Sub(remaining, take)
+	}
+	if remaining.Sign() > 0 || len(quotes) == 0 {
+		return quotes, schema.ErrInsufficientLockAmount
+	}
+	return quotes, nil
+}
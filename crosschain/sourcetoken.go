@@ -0,0 +1,149 @@
+package crosschain
+
+import (
+	"maps"
+	"strconv"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+// isBridgeAdmin reports whether an account can manage the source token registry
+func (t *Token) isBridgeAdmin(from string) bool {
+	bridgeAdmin := t.db.GetBridgeAdmin()
+	return from == t.basic.DB.Owner() || (bridgeAdmin != "" && from == bridgeAdmin)
+}
+
+// parseSourceTokenId validates and normalizes the SourceTokenId param
+func parseSourceTokenId(params map[string]string) (string, error) {
+	if params["SourceTokenId"] == "" {
+		return "", schema.ErrMissingSourceTokenId
+	}
+	_, sourceTokenId, err := utils.IDCheck(params["SourceTokenId"])
+	if err != nil {
+		return "", schema.ErrInvalidSourceTokenId
+	}
+	return sourceTokenId, nil
+}
+
+// applySourceTokenParams applies the SourceChainType, Name and Decimals params to a source token,
+// an empty param keeps the current value
+func applySourceTokenParams(sourceToken schema.SourceToken, params map[string]string) (schema.SourceToken, error) {
+	if params["SourceChainType"] != "" {
+		sourceToken.ChainType = params["SourceChainType"]
+	}
+	if params["Name"] != "" {
+		sourceToken.Name = params["Name"]
+	}
+	if params["Decimals"] != "" {
+		if _, err := strconv.ParseUint(params["Decimals"], 10, 8); err != nil {
+			return sourceToken, schema.ErrInvalidSourceToken
+		}
+		sourceToken.Decimals = params["Decimals"]
+	}
+	if sourceToken.ChainType == "" {
+		return sourceToken, schema.ErrMissingSourceChain
+	}
+	return sourceToken, nil
+}
+
+func (t *Token) sourceTokenResult(from, notice string) (res vmmSchema.Result) {
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: notice, Value: "success"},
+			},
+		},
+	}
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	return
+}
+
+// handleRegisterSourceToken registers a source token that can then be minted (Owner or BridgeAdmin only)
+func (t *Token) handleRegisterSourceToken(from string, params map[string]string) (res vmmSchema.Result) {
+	if !t.isBridgeAdmin(from) {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	sourceTokenId, err := parseSourceTokenId(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if _, registered := t.db.GetSourceToken(sourceTokenId); registered {
+		res.Error = schema.ErrSourceTokenRegistered
+		return
+	}
+
+	sourceToken, err := applySourceTokenParams(schema.SourceToken{TokenId: sourceTokenId}, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	t.db.SetSourceToken(sourceToken)
+	return t.sourceTokenResult(from, "Register-Source-Token-Notice")
+}
+
+// handleUpdateSourceToken changes the chain type or the metadata of a source token while nothing
+// is locked for it, so no locked amount is left under the previous chain type (Owner or BridgeAdmin only)
+func (t *Token) handleUpdateSourceToken(from string, params map[string]string) (res vmmSchema.Result) {
+	if !t.isBridgeAdmin(from) {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	sourceTokenId, err := parseSourceTokenId(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	sourceToken, registered := t.db.GetSourceToken(sourceTokenId)
+	if !registered {
+		res.Error = schema.ErrUnregisteredSourceToken
+		return
+	}
+	if lockAmt, _ := t.db.GetSourceLockAmount(sourceTokenId, sourceToken.ChainType); lockAmt.Sign() != 0 {
+		res.Error = schema.ErrSourceTokenLocked
+		return
+	}
+
+	sourceToken, err = applySourceTokenParams(sourceToken, params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	t.db.SetSourceToken(sourceToken)
+	return t.sourceTokenResult(from, "Update-Source-Token-Notice")
+}
+
+// handleDisableSourceToken stops the mints of a source token, Disabled false enables it again
+// (Owner or BridgeAdmin only). Burns still release its locked amount.
+func (t *Token) handleDisableSourceToken(from string, params map[string]string) (res vmmSchema.Result) {
+	if !t.isBridgeAdmin(from) {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+	sourceTokenId, err := parseSourceTokenId(params)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	sourceToken, registered := t.db.GetSourceToken(sourceTokenId)
+	if !registered {
+		res.Error = schema.ErrUnregisteredSourceToken
+		return
+	}
+
+	sourceToken.Disabled = true
+	if disabledStr := params["Disabled"]; disabledStr != "" {
+		if sourceToken.Disabled, err = strconv.ParseBool(disabledStr); err != nil {
+			res.Error = schema.ErrInvalidSourceToken
+			return
+		}
+	}
+	t.db.SetSourceToken(sourceToken)
+	return t.sourceTokenResult(from, "Disable-Source-Token-Notice")
+}
@@ -0,0 +1,183 @@
+package crosschain
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+)
+
+// ChainValidator validates the addresses and the transaction hashes of a chain type,
+// and returns them in their canonical form
+type ChainValidator struct {
+	Address func(address string) (string, bool)
+	TxHash  func(txHash string) (string, bool)
+}
+
+// defaultValidator keeps the Arweave and EVM addresses of the chain types without a validator,
+// their transaction hashes are only required
+var defaultValidator = ChainValidator{
+	Address: func(address string) (string, bool) {
+		_, address, err := utils.IDCheck(address)
+		return address, err == nil
+	},
+	TxHash: func(txHash string) (string, bool) {
+		return txHash, txHash != ""
+	},
+}
+
+var evmValidator = ChainValidator{Address: evmAddress, TxHash: hexTxHash("0x", false)}
+
+// chainValidators are the validators keyed by chain type
+var chainValidators = map[string]ChainValidator{
+	"ethereum":  evmValidator,
+	"bsc":       evmValidator,
+	"polygon":   evmValidator,
+	"arbitrum":  evmValidator,
+	"optimism":  evmValidator,
+	"base":      evmValidator,
+	"avalanche": evmValidator,
+	"bitcoin":   {Address: bitcoinAddress, TxHash: hexTxHash("", false)},
+	"solana":    {Address: base58Value(32), TxHash: base58Value(64)},
+	"cosmos":    {Address: bech32Address("cosmos"), TxHash: hexTxHash("", true)},
+	"osmosis":   {Address: bech32Address("osmo"), TxHash: hexTxHash("", true)},
+	"arweave":   {Address: arweaveId, TxHash: arweaveId},
+}
+
+// RegisterChainValidator sets the validator of a chain type, it must be called before the tokens are spawned
+func RegisterChainValidator(chainType string, validator ChainValidator) {
+	chainValidators[chainType] = validator
+}
+
+// chainValidator returns the validator of a chain type, else the default validator
+func chainValidator(chainType string) ChainValidator {
+	if validator, ok := chainValidators[chainType]; ok {
+		return validator
+	}
+	return defaultValidator
+}
+
+// evmAddress checks a hex address and returns its EIP-55 checksum form
+func evmAddress(address string) (string, bool) {
+	if !strings.HasPrefix(address, "0x") || !common.IsHexAddress(address) {
+		return "", false
+	}
+	return common.HexToAddress(address).Hex(), true
+}
+
+// hexTxHash checks 32 byte hex hashes with an optional 0x prefix, and returns them with the prefix
+// in lower or upper case
+func hexTxHash(prefix string, upper bool) func(string) (string, bool) {
+	return func(txHash string) (string, bool) {
+		txHash = strings.TrimPrefix(strings.TrimPrefix(txHash, "0x"), "0X")
+		if b, err := hex.DecodeString(txHash); err != nil || len(b) != 32 {
+			return "", false
+		}
+		if upper {
+			return prefix + strings.ToUpper(txHash), true
+		}
+		return prefix + strings.ToLower(txHash), true
+	}
+}
+
+// base58Value checks base58 values of a byte size, e.g. Solana public keys and signatures
+func base58Value(size int) func(string) (string, bool) {
+	return func(value string) (string, bool) {
+		decoded := base58.Decode(value)
+		if len(decoded) != size || base58.Encode(decoded) != value {
+			return "", false
+		}
+		return value, true
+	}
+}
+
+// bech32Address checks bech32 addresses of a human readable part with a 20 or 32 byte payload,
+// and returns them in lower case
+func bech32Address(hrp string) func(string) (string, bool) {
+	return func(address string) (string, bool) {
+		addrHrp, data, err := bech32.Decode(address)
+		if err != nil || addrHrp != hrp {
+			return "", false
+		}
+		payload, err := bech32.ConvertBits(data, 5, 8, false)
+		if err != nil || (len(payload) != 20 && len(payload) != 32) {
+			return "", false
+		}
+		return strings.ToLower(address), true
+	}
+}
+
+// bitcoinAddress checks mainnet P2PKH and P2SH base58check addresses and segwit addresses,
+// bech32 for witness version 0 and bech32m for the later versions. Segwit addresses are returned in lower case.
+func bitcoinAddress(address string) (string, bool) {
+	if !strings.HasPrefix(strings.ToLower(address), "bc1") {
+		payload, version, err := base58.CheckDecode(address)
+		if err != nil || len(payload) != 20 || (version != 0x00 && version != 0x05) {
+			return "", false
+		}
+		return address, true
+	}
+
+	hrp, data, bechVersion, err := bech32.DecodeGeneric(address)
+	if err != nil || hrp != "bc" || len(data) == 0 {
+		return "", false
+	}
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", false
+	}
+	switch witnessVersion := data[0]; {
+	case witnessVersion == 0:
+		if bechVersion != bech32.Version0 || (len(program) != 20 && len(program) != 32) {
+			return "", false
+		}
+	case witnessVersion <= 16:
+		if bechVersion != bech32.VersionM || len(program) < 2 || len(program) > 40 {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return strings.ToLower(address), true
+}
+
+// arweaveId checks Arweave addresses and transaction ids
+func arweaveId(id string) (string, bool) {
+	accountType, id, err := utils.IDCheck(id)
+	return id, err == nil && accountType == vmmSchema.AccountTypeAR
+}
+
+// parseRecipient validates and normalizes the recipient of a burn to a chain type
+func parseRecipient(chainType, recipient string) (string, error) {
+	recipient, ok := chainValidator(chainType).Address(recipient)
+	if !ok {
+		return "", schema.ErrInvalidRecipient
+	}
+	return recipient, nil
+}
+
+// parseMintTxHash validates and normalizes the transaction hash of a mint from a chain type
+func parseMintTxHash(chainType, mintTxHash string) (string, error) {
+	if mintTxHash == "" {
+		return "", schema.ErrMissingMintTxHash
+	}
+	mintTxHash, ok := chainValidator(chainType).TxHash(mintTxHash)
+	if !ok {
+		return "", schema.ErrInvalidMintTxHash
+	}
+	return mintTxHash, nil
+}
+
+// canonicalMintTxHash returns a mint tx hash in the canonical form of its chain type to look it up,
+// a hash the chain type doesn't validate is returned as is
+func canonicalMintTxHash(chainType, mintTxHash string) string {
+	if canonical, ok := chainValidator(chainType).TxHash(mintTxHash); ok {
+		return canonical
+	}
+	return mintTxHash
+}
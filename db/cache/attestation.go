@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// RelayerSet returns the relayers attesting mints and the attestation threshold
+func (c *CrossChainToken) RelayerSet() (relayers []string, threshold int) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return append([]string(nil), c.relayers...), c.relayerThreshold
+}
+
+// SetRelayerSet sets the relayers, a zero threshold disables the attested minting
+func (c *CrossChainToken) SetRelayerSet(relayers []string, threshold int) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.relayers = append([]string(nil), relayers...)
+	c.relayerThreshold = threshold
+}
+
+func copyMintAttestation(attestation schema.MintAttestation) schema.MintAttestation {
+	attestation.Attesters = append([]string(nil), attestation.Attesters...)
+	attestation.Conflicts = append([]string(nil), attestation.Conflicts...)
+	return attestation
+}
+
+// MintAttestation gets the attestations of a mint transaction hash
+func (c *CrossChainToken) MintAttestation(mintTxHash string) (schema.MintAttestation, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	attestation, exists := c.mintAttestations[mintTxHash]
+	return copyMintAttestation(attestation), exists
+}
+
+// SetMintAttestation stores the attestations of a mint transaction hash
+func (c *CrossChainToken) SetMintAttestation(attestation schema.MintAttestation) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.mintAttestations == nil {
+		c.mintAttestations = make(map[string]schema.MintAttestation)
+	}
+	c.mintAttestations[attestation.MintTxHash] = copyMintAttestation(attestation)
+}
+
+// DeleteMintAttestation removes the attestations of a mint transaction hash
+func (c *CrossChainToken) DeleteMintAttestation(mintTxHash string) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	delete(c.mintAttestations, mintTxHash)
+}
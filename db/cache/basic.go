@@ -20,9 +20,38 @@ type BasicToken struct {
 	mintOwner   string
 	initialSync bool
 	rwlock      sync.RWMutex
+
+	holds          map[string]schema.Hold         // key: holdId
+	holdAllowances map[string]map[string]*big.Int // key: accId, merchant
+
+	complianceOwner string
+	frozen          map[string]bool // key: accId
+	auditLog        []schema.AuditRecord
+
+	policy schema.Policy
+
+	redenominateSigners   []string
+	redenominateThreshold int
+	pendingRedenomination *schema.Redenomination
+
+	rewardPerToken      map[string]*big.Int                        // key: rewardToken
+	rewardAccounts      map[string]map[string]schema.RewardAccount // key: accId, rewardToken
+	rewardExclusions    []string
+	allowedRewardTokens []string
+
+	twabs           map[string]schema.Twab // key: accId
+	totalSupplyTwab *schema.Twab
+
+	emission     *schema.Emission
+	emitted      *big.Int
+	lastEmission int64
+
+	idempotencyRecords map[string]schema.IdempotencyRecord // key: sender:key
+	idempotencyOrder   []string                            // keys in insertion order
+	idempotencyCounts  map[string]int                      // key: sender
 }
 
-func NewBasicToken(info schema.Info, owner string, mintOwner string, maxSupply *big.Int) *BasicToken {
+func NewBasicToken(info schema.Info, owner string, mintOwner string, complianceOwner string, maxSupply *big.Int) *BasicToken {
 	_, mintOwner, _ = utils.IDCheck(mintOwner)
 	return &BasicToken{
 		info:        info,
@@ -33,6 +62,23 @@ func NewBasicToken(info schema.Info, owner string, mintOwner string, maxSupply *
 		mintOwner:   mintOwner,
 		initialSync: false,
 		rwlock:      sync.RWMutex{},
+
+		holds:          make(map[string]schema.Hold),
+		holdAllowances: make(map[string]map[string]*big.Int),
+
+		complianceOwner: complianceOwner,
+		frozen:          make(map[string]bool),
+		auditLog:        make([]schema.AuditRecord, 0),
+
+		rewardPerToken: make(map[string]*big.Int),
+		rewardAccounts: make(map[string]map[string]schema.RewardAccount),
+
+		twabs: make(map[string]schema.Twab),
+
+		emitted: big.NewInt(0),
+
+		idempotencyRecords: make(map[string]schema.IdempotencyRecord),
+		idempotencyCounts:  make(map[string]int),
 	}
 }
 
@@ -93,6 +139,33 @@ func (b *BasicToken) SetMaxSupply(newMaxSupply *big.Int) error {
 	return nil
 }
 
+func (b *BasicToken) ComplianceOwner() string {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return b.complianceOwner
+}
+
+func (b *BasicToken) SetComplianceOwner(newOwner string) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.complianceOwner = newOwner
+}
+
+func (b *BasicToken) Policy() schema.Policy {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	policy := b.policy
+	policy.Allowlist = append([]string(nil), b.policy.Allowlist...)
+	policy.TransferWindows = append([]schema.TransferWindow(nil), b.policy.TransferWindows...)
+	return policy
+}
+
+func (b *BasicToken) SetPolicy(policy schema.Policy) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.policy = policy
+}
+
 func (b *BasicToken) GetTotalSupply() *big.Int {
 	b.rwlock.RLock()
 	defer b.rwlock.RUnlock()
@@ -185,6 +258,33 @@ func (b *BasicToken) Checkpoint() (data string, err error) {
 		Owner:       b.owner,
 		MintOwner:   b.mintOwner,
 		MaxSupply:   b.maxSupply,
+
+		Holds:          b.holds,
+		HoldAllowances: b.holdAllowances,
+
+		ComplianceOwner: b.complianceOwner,
+		Frozen:          b.frozen,
+		AuditLog:        b.auditLog,
+
+		Policy: b.policy,
+
+		RedenominateSigners:   b.redenominateSigners,
+		RedenominateThreshold: b.redenominateThreshold,
+		PendingRedenomination: b.pendingRedenomination,
+
+		RewardPerToken:      b.rewardPerToken,
+		RewardAccounts:      b.rewardAccounts,
+		RewardExclusions:    b.rewardExclusions,
+		AllowedRewardTokens: b.allowedRewardTokens,
+
+		Twabs:           b.twabs,
+		TotalSupplyTwab: b.totalSupplyTwab,
+
+		Emission:     b.emission,
+		Emitted:      b.emitted,
+		LastEmission: b.lastEmission,
+
+		IdempotencyRecords: b.idempotencySnapshot(),
 	}
 	by, err := json.Marshal(snap)
 	if err != nil {
@@ -214,6 +314,52 @@ func (b *BasicToken) Restore(data string) error {
 	if b.maxSupply == nil {
 		b.maxSupply = big.NewInt(0)
 	}
+	b.holds = snap.Holds
+	if b.holds == nil {
+		b.holds = make(map[string]schema.Hold)
+	}
+	b.holdAllowances = snap.HoldAllowances
+	if b.holdAllowances == nil {
+		b.holdAllowances = make(map[string]map[string]*big.Int)
+	}
+	b.complianceOwner = snap.ComplianceOwner
+	if b.complianceOwner == "" {
+		b.complianceOwner = snap.Owner
+	}
+	b.frozen = snap.Frozen
+	if b.frozen == nil {
+		b.frozen = make(map[string]bool)
+	}
+	b.auditLog = snap.AuditLog
+	if b.auditLog == nil {
+		b.auditLog = make([]schema.AuditRecord, 0)
+	}
+	b.policy = snap.Policy
+	b.redenominateSigners = snap.RedenominateSigners
+	b.redenominateThreshold = snap.RedenominateThreshold
+	b.pendingRedenomination = snap.PendingRedenomination
+	b.rewardPerToken = snap.RewardPerToken
+	if b.rewardPerToken == nil {
+		b.rewardPerToken = make(map[string]*big.Int)
+	}
+	b.rewardAccounts = snap.RewardAccounts
+	if b.rewardAccounts == nil {
+		b.rewardAccounts = make(map[string]map[string]schema.RewardAccount)
+	}
+	b.rewardExclusions = snap.RewardExclusions
+	b.allowedRewardTokens = snap.AllowedRewardTokens
+	b.twabs = snap.Twabs
+	if b.twabs == nil {
+		b.twabs = make(map[string]schema.Twab)
+	}
+	b.totalSupplyTwab = snap.TotalSupplyTwab
+	b.emission = snap.Emission
+	b.emitted = snap.Emitted
+	if b.emitted == nil {
+		b.emitted = big.NewInt(0)
+	}
+	b.lastEmission = snap.LastEmission
+	b.restoreIdempotency(snap.IdempotencyRecords)
 	b.info = schema.Info{
 		Id:          snap.Id,
 		Name:        snap.Name,
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"maps"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyBridgeFlow(flow schema.BridgeFlow) schema.BridgeFlow {
+	flow.Inflows = append([]schema.FlowEntry(nil), flow.Inflows...)
+	flow.Outflows = append([]schema.FlowEntry(nil), flow.Outflows...)
+	return flow
+}
+
+// BridgeLimits returns a copy of the bridge limits
+func (c *CrossChainToken) BridgeLimits() map[string]schema.BridgeLimit {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return maps.Clone(c.bridgeLimits)
+}
+
+// BridgeLimit gets the bridge limit of a chain type or of a chainType:tokenId key
+func (c *CrossChainToken) BridgeLimit(key string) (schema.BridgeLimit, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	limit, exists := c.bridgeLimits[key]
+	return limit, exists
+}
+
+// SetBridgeLimit sets the bridge limit of a key, nil removes it
+func (c *CrossChainToken) SetBridgeLimit(key string, limit *schema.BridgeLimit) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if limit == nil {
+		delete(c.bridgeLimits, key)
+		return
+	}
+	if c.bridgeLimits == nil {
+		c.bridgeLimits = make(map[string]schema.BridgeLimit)
+	}
+	c.bridgeLimits[key] = *limit
+}
+
+// BridgeFlows returns a copy of the recent mints and burns of every key
+func (c *CrossChainToken) BridgeFlows() map[string]schema.BridgeFlow {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	result := make(map[string]schema.BridgeFlow, len(c.bridgeFlows))
+	for key, flow := range c.bridgeFlows {
+		result[key] = copyBridgeFlow(flow)
+	}
+	return result
+}
+
+// BridgeFlow gets a copy of the recent mints and burns of a key
+func (c *CrossChainToken) BridgeFlow(key string) schema.BridgeFlow {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return copyBridgeFlow(c.bridgeFlows[key])
+}
+
+// SetBridgeFlow sets the recent mints and burns of a key, an empty flow removes it
+func (c *CrossChainToken) SetBridgeFlow(key string, flow schema.BridgeFlow) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if len(flow.Inflows) == 0 && len(flow.Outflows) == 0 {
+		delete(c.bridgeFlows, key)
+		return
+	}
+	if c.bridgeFlows == nil {
+		c.bridgeFlows = make(map[string]schema.BridgeFlow)
+	}
+	c.bridgeFlows[key] = copyBridgeFlow(flow)
+}
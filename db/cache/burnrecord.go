@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// GetBurnRecord gets the record of a burn
+func (c *CrossChainToken) GetBurnRecord(burnTxHash string) (schema.BurnRecord, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	record, exists := c.burnRecords[burnTxHash]
+	return record, exists
+}
+
+// AddBurnRecord stores the record of a burn, records are kept in insertion order
+// and an existing record is replaced in place
+func (c *CrossChainToken) AddBurnRecord(record schema.BurnRecord) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.burnRecords == nil {
+		c.burnRecords = make(map[string]schema.BurnRecord)
+	}
+	if _, exists := c.burnRecords[record.BurnTxHash]; !exists {
+		c.burnOrder = append(c.burnOrder, record.BurnTxHash)
+	}
+	c.burnRecords[record.BurnTxHash] = record
+}
+
+// BurnRecords returns the records of a chain, or of all chains when empty, in insertion order
+func (c *CrossChainToken) BurnRecords(chainType string) []schema.BurnRecord {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	records := make([]schema.BurnRecord, 0)
+	for _, burnTxHash := range c.burnOrder {
+		if record := c.burnRecords[burnTxHash]; chainType == "" || record.TargetChainType == chainType {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// BurnRecordMaxAge gets the age after which resolved burn records are pruned
+func (c *CrossChainToken) BurnRecordMaxAge() int64 {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return c.burnRecordMaxAge
+}
+
+// SetBurnRecordMaxAge sets the age after which resolved burn records are pruned, 0 keeps them
+func (c *CrossChainToken) SetBurnRecordMaxAge(maxAge int64) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.burnRecordMaxAge = maxAge
+}
+
+// PruneBurnRecords drops the resolved records older than the burn record MaxAge
+func (c *CrossChainToken) PruneBurnRecords(now int64) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	maxAge := c.burnRecordMaxAge
+	if maxAge == 0 {
+		return
+	}
+	order := c.burnOrder[:0]
+	for _, burnTxHash := range c.burnOrder {
+		record := c.burnRecords[burnTxHash]
+		if record.Status == schema.BurnStatusPending || record.Timestamp >= now-maxAge {
+			order = append(order, burnTxHash)
+			continue
+		}
+		delete(c.burnRecords, burnTxHash)
+	}
+	c.burnOrder = order
+}
+
+// burnSnapshot returns the records in insertion order, the caller must hold the lock
+func (c *CrossChainToken) burnSnapshot() []schema.BurnRecord {
+	records := make([]schema.BurnRecord, 0, len(c.burnOrder))
+	for _, burnTxHash := range c.burnOrder {
+		records = append(records, c.burnRecords[burnTxHash])
+	}
+	return records
+}
+
+// restoreBurnRecords rebuilds the records from a snapshot, the caller must hold the lock
+func (c *CrossChainToken) restoreBurnRecords(records []schema.BurnRecord) {
+	c.burnRecords = make(map[string]schema.BurnRecord, len(records))
+	c.burnOrder = make([]string, 0, len(records))
+	for _, record := range records {
+		if _, exists := c.burnRecords[record.BurnTxHash]; !exists {
+			c.burnOrder = append(c.burnOrder, record.BurnTxHash)
+		}
+		c.burnRecords[record.BurnTxHash] = record
+	}
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyBurnRoute(route schema.BurnRoute) schema.BurnRoute {
+	route.Preferred = append([]string(nil), route.Preferred...)
+	return route
+}
+
+// BurnRoutes returns a copy of the burn routes
+func (c *CrossChainToken) BurnRoutes() map[string]schema.BurnRoute {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	result := make(map[string]schema.BurnRoute, len(c.burnRoutes))
+	for chainType, route := range c.burnRoutes {
+		result[chainType] = copyBurnRoute(route)
+	}
+	return result
+}
+
+// BurnRoute gets the burn route of a chain type
+func (c *CrossChainToken) BurnRoute(chainType string) (schema.BurnRoute, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	route, exists := c.burnRoutes[chainType]
+	return copyBurnRoute(route), exists
+}
+
+// SetBurnRoute sets the burn route of a chain type, nil removes it
+func (c *CrossChainToken) SetBurnRoute(chainType string, route *schema.BurnRoute) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if route == nil {
+		delete(c.burnRoutes, chainType)
+		return
+	}
+	if c.burnRoutes == nil {
+		c.burnRoutes = make(map[string]schema.BurnRoute)
+	}
+	c.burnRoutes[chainType] = copyBurnRoute(*route)
+}
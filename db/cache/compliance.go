@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"sort"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+)
+
+// IsFrozen reports whether an account is frozen
+func (b *BasicToken) IsFrozen(accId string) bool {
+	_, accId, err := utils.IDCheck(accId)
+	if err != nil {
+		return false
+	}
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return b.frozen[accId]
+}
+
+// SetFrozen freezes or unfreezes an account
+func (b *BasicToken) SetFrozen(accId string, frozen bool) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.frozen == nil {
+		b.frozen = make(map[string]bool)
+	}
+	if frozen {
+		b.frozen[accId] = true
+	} else {
+		delete(b.frozen, accId)
+	}
+}
+
+// FrozenAccounts returns all frozen accounts in sorted order
+func (b *BasicToken) FrozenAccounts() []string {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	result := make([]string, 0, len(b.frozen))
+	for accId := range b.frozen {
+		result = append(result, accId)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AppendAuditRecord appends a record to the admin audit log
+func (b *BasicToken) AppendAuditRecord(record schema.AuditRecord) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.auditLog = append(b.auditLog, record)
+}
+
+// AuditRecords returns a copy of the admin audit log
+func (b *BasicToken) AuditRecords() []schema.AuditRecord {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	result := make([]schema.AuditRecord, len(b.auditLog))
+	copy(result, b.auditLog)
+	return result
+}
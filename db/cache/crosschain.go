@@ -6,22 +6,62 @@ import (
 	"sync"
 
 	dbSchema "github.com/aox-labs/hymx-vmtoken/db/cache/schema"
+	"github.com/aox-labs/hymx-vmtoken/schema"
 )
 
 type CrossChainToken struct {
-	mintedRecords     map[string]string   // key: X-MintTxHash val: chainType
-	sourceTokenChains map[string]string   // key: sourceTokenId, val: sourceChainType
-	sourceLockAmounts map[string]*big.Int // key: sourceChain:sourceTokenId, val: source chain locked amount
-	burnFees          map[string]*big.Int // key: chainType, val: burn fee
+	mintedRecords     map[string]schema.MintRecord // key: X-MintTxHash
+	mintOrder         []string                     // X-MintTxHash in insertion order
+	mintRetention     schema.MintRetention
+	mintLatestHeights map[string]uint64             // key: chainType, val: highest seen block height
+	mintWatermarks    map[string]uint64             // key: chainType, val: highest pruned block height
+	mintArchive       map[string]map[string]uint64  // key: chainType, X-MintTxHash pruned by age, val: block height
+	burnRecords       map[string]schema.BurnRecord  // key: BurnTxHash
+	burnOrder         []string                      // BurnTxHash in insertion order
+	burnRecordMaxAge  int64                         // UnixMilli, 0 keeps the resolved records
+	sourceTokens      map[string]schema.SourceToken // key: sourceTokenId
+	sourceLockAmounts map[string]*big.Int           // key: sourceChain:sourceTokenId, val: source chain locked amount
+	burnFees          map[string]*big.Int           // key: chainType, val: burn fee
+	burnFeeModels     map[string]schema.FeeModel    // key: chainType or chainType:targetTokenId
+	mintFeeModels     map[string]schema.FeeModel    // key: chainType or chainType:sourceTokenId
+	feeLedgers        map[string]schema.FeeLedger   // key: chainType
+	feeShares         []schema.FeeShare
+	burnRoutes        map[string]schema.BurnRoute // key: chainType
+	lockAuditLog      []schema.LockAuditRecord
+	reserveAttestors  []string
+	reserveTolerance  int64                            // basis points
+	reserveReports    map[string]schema.ReserveReport  // key: sourceTokenId
+	haltedChains      map[string]schema.InvariantAlert // key: chainType
+	bridgeLimits      map[string]schema.BridgeLimit    // key: chainType or chainType:tokenId
+	bridgeFlows       map[string]schema.BridgeFlow     // key: chainType or chainType:tokenId
 	feeRecipient      string
 	burnProcessor     string
+	bridgeAdmin       string
+	dustPolicy        string
+	relayers          []string
+	relayerThreshold  int
+	mintAttestations  map[string]schema.MintAttestation // key: X-MintTxHash
 	rwlock            sync.RWMutex
 }
 
 func NewCrossChainToken(burnFees map[string]*big.Int, feeRecipient string, burnProcessor string) *CrossChainToken {
 	return &CrossChainToken{
-		mintedRecords:     make(map[string]string),
-		sourceTokenChains: make(map[string]string),
+		mintedRecords:     make(map[string]schema.MintRecord),
+		mintLatestHeights: make(map[string]uint64),
+		mintWatermarks:    make(map[string]uint64),
+		mintArchive:       make(map[string]map[string]uint64),
+		burnRecords:       make(map[string]schema.BurnRecord),
+		sourceTokens:      make(map[string]schema.SourceToken),
+		mintAttestations:  make(map[string]schema.MintAttestation),
+		burnFeeModels:     make(map[string]schema.FeeModel),
+		mintFeeModels:     make(map[string]schema.FeeModel),
+		feeLedgers:        make(map[string]schema.FeeLedger),
+		burnRoutes:        make(map[string]schema.BurnRoute),
+		lockAuditLog:      make([]schema.LockAuditRecord, 0),
+		reserveReports:    make(map[string]schema.ReserveReport),
+		haltedChains:      make(map[string]schema.InvariantAlert),
+		bridgeLimits:      make(map[string]schema.BridgeLimit),
+		bridgeFlows:       make(map[string]schema.BridgeFlow),
 		sourceLockAmounts: make(map[string]*big.Int),
 		burnFees:          burnFees,
 		feeRecipient:      feeRecipient,
@@ -30,53 +70,6 @@ func NewCrossChainToken(burnFees map[string]*big.Int, feeRecipient string, burnP
 	}
 }
 
-// GetMintedRecord gets the source chain type for a mint transaction hash
-func (c *CrossChainToken) GetMintedRecord(mintTxHash string) (string, bool) {
-	c.rwlock.RLock()
-	defer c.rwlock.RUnlock()
-	chainType, exists := c.mintedRecords[mintTxHash]
-	return chainType, exists
-}
-
-// SetMintedRecord sets the source chain type for a mint transaction hash
-func (c *CrossChainToken) SetMintedRecord(mintTxHash, sourceChainType string) {
-	c.rwlock.Lock()
-	defer c.rwlock.Unlock()
-	if c.mintedRecords == nil {
-		c.mintedRecords = make(map[string]string)
-	}
-	c.mintedRecords[mintTxHash] = sourceChainType
-}
-
-// GetSourceTokenChains returns a copy of all source token chains
-func (c *CrossChainToken) GetSourceTokenChains() map[string]string {
-	c.rwlock.RLock()
-	defer c.rwlock.RUnlock()
-	result := make(map[string]string, len(c.sourceTokenChains))
-	for k, v := range c.sourceTokenChains {
-		result[k] = v
-	}
-	return result
-}
-
-// GetSourceTokenChain gets the chain type for a source token ID
-func (c *CrossChainToken) GetSourceTokenChain(tokenId string) (string, bool) {
-	c.rwlock.RLock()
-	defer c.rwlock.RUnlock()
-	chainType, exists := c.sourceTokenChains[tokenId]
-	return chainType, exists
-}
-
-// SetSourceTokenChain sets the chain type for a source token ID
-func (c *CrossChainToken) SetSourceTokenChain(tokenId, chainType string) {
-	c.rwlock.Lock()
-	defer c.rwlock.Unlock()
-	if c.sourceTokenChains == nil {
-		c.sourceTokenChains = make(map[string]string)
-	}
-	c.sourceTokenChains[tokenId] = chainType
-}
-
 // GetSourceLockAmounts returns a copy of all source lock amounts
 func (c *CrossChainToken) GetSourceLockAmounts() map[string]*big.Int {
 	c.rwlock.RLock()
@@ -185,15 +178,52 @@ func (c *CrossChainToken) SetBurnProcessor(addr string) {
 	c.burnProcessor = addr
 }
 
+// GetDustPolicy gets the dust policy of the decimal conversions
+func (c *CrossChainToken) GetDustPolicy() string {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return c.dustPolicy
+}
+
+// SetDustPolicy sets the dust policy of the decimal conversions
+func (c *CrossChainToken) SetDustPolicy(dustPolicy string) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.dustPolicy = dustPolicy
+}
+
 // Checkpoint creates a snapshot of the cross-chain token state
 func (c *CrossChainToken) Checkpoint() (data string, err error) {
 	c.rwlock.RLock()
 	defer c.rwlock.RUnlock()
 	snap := dbSchema.CrossChainMultiSnapshot{
-		MintedRecords:     c.mintedRecords,
-		SourceTokenChains: c.sourceTokenChains,
+		MintRecords:       c.mintSnapshot(),
+		MintRetention:     c.mintRetention,
+		MintLatestHeights: c.mintLatestHeights,
+		MintWatermarks:    c.mintWatermarks,
+		MintArchive:       c.mintArchive,
+		BurnRecords:       c.burnSnapshot(),
+		BurnRecordMaxAge:  c.burnRecordMaxAge,
+		SourceTokens:      c.sourceTokens,
+		BridgeAdmin:       c.bridgeAdmin,
+		DustPolicy:        c.dustPolicy,
+		Relayers:          c.relayers,
+		RelayerThreshold:  c.relayerThreshold,
+		MintAttestations:  c.mintAttestations,
 		SourceLockAmounts: c.sourceLockAmounts,
 		BurnFees:          c.burnFees,
+		BurnFeeModels:     c.burnFeeModels,
+		MintFeeModels:     c.mintFeeModels,
+		FeeLedgers:        c.feeLedgers,
+		FeeShares:         c.feeShares,
+		BurnRoutes:        c.burnRoutes,
+		LockAuditLog:      c.lockAuditLog,
+		ReserveAttestors:  c.reserveAttestors,
+		ReserveTolerance:  c.reserveTolerance,
+		ReserveReports:    c.reserveReports,
+		HaltedChains:      c.haltedChains,
+		BridgeLimits:      c.bridgeLimits,
+		BridgeFlows:       c.bridgeFlows,
 		FeeRecipient:      c.feeRecipient,
 		BurnProcessor:     c.burnProcessor,
 	}
@@ -212,13 +242,33 @@ func (c *CrossChainToken) Restore(data string) error {
 	}
 	c.rwlock.Lock()
 	defer c.rwlock.Unlock()
-	c.mintedRecords = snap.MintedRecords
-	if c.mintedRecords == nil {
-		c.mintedRecords = make(map[string]string)
+	c.restoreMintRecords(snap.MintRecords, snap.MintedRecords)
+	c.mintRetention = snap.MintRetention
+	c.mintLatestHeights = snap.MintLatestHeights
+	if c.mintLatestHeights == nil {
+		c.mintLatestHeights = make(map[string]uint64)
+	}
+	c.mintWatermarks = snap.MintWatermarks
+	if c.mintWatermarks == nil {
+		c.mintWatermarks = make(map[string]uint64)
 	}
-	c.sourceTokenChains = snap.SourceTokenChains
-	if c.sourceTokenChains == nil {
-		c.sourceTokenChains = make(map[string]string)
+	c.mintArchive = snap.MintArchive
+	if c.mintArchive == nil {
+		c.mintArchive = make(map[string]map[string]uint64)
+	}
+	c.restoreBurnRecords(snap.BurnRecords)
+	c.burnRecordMaxAge = snap.BurnRecordMaxAge
+	c.restoreSourceTokens(snap.SourceTokens, snap.SourceTokenChains)
+	c.bridgeAdmin = snap.BridgeAdmin
+	c.dustPolicy = snap.DustPolicy
+	if c.dustPolicy == "" {
+		c.dustPolicy = schema.DustPolicyReject
+	}
+	c.relayers = snap.Relayers
+	c.relayerThreshold = snap.RelayerThreshold
+	c.mintAttestations = snap.MintAttestations
+	if c.mintAttestations == nil {
+		c.mintAttestations = make(map[string]schema.MintAttestation)
 	}
 	c.sourceLockAmounts = snap.SourceLockAmounts
 	if c.sourceLockAmounts == nil {
@@ -228,6 +278,45 @@ func (c *CrossChainToken) Restore(data string) error {
 	if c.burnFees == nil {
 		c.burnFees = make(map[string]*big.Int)
 	}
+	c.burnFeeModels = snap.BurnFeeModels
+	if c.burnFeeModels == nil {
+		c.burnFeeModels = make(map[string]schema.FeeModel)
+	}
+	c.mintFeeModels = snap.MintFeeModels
+	if c.mintFeeModels == nil {
+		c.mintFeeModels = make(map[string]schema.FeeModel)
+	}
+	c.feeLedgers = snap.FeeLedgers
+	if c.feeLedgers == nil {
+		c.feeLedgers = make(map[string]schema.FeeLedger)
+	}
+	c.feeShares = snap.FeeShares
+	c.burnRoutes = snap.BurnRoutes
+	if c.burnRoutes == nil {
+		c.burnRoutes = make(map[string]schema.BurnRoute)
+	}
+	c.lockAuditLog = snap.LockAuditLog
+	if c.lockAuditLog == nil {
+		c.lockAuditLog = make([]schema.LockAuditRecord, 0)
+	}
+	c.reserveAttestors = snap.ReserveAttestors
+	c.reserveTolerance = snap.ReserveTolerance
+	c.reserveReports = snap.ReserveReports
+	if c.reserveReports == nil {
+		c.reserveReports = make(map[string]schema.ReserveReport)
+	}
+	c.haltedChains = snap.HaltedChains
+	if c.haltedChains == nil {
+		c.haltedChains = make(map[string]schema.InvariantAlert)
+	}
+	c.bridgeLimits = snap.BridgeLimits
+	if c.bridgeLimits == nil {
+		c.bridgeLimits = make(map[string]schema.BridgeLimit)
+	}
+	c.bridgeFlows = snap.BridgeFlows
+	if c.bridgeFlows == nil {
+		c.bridgeFlows = make(map[string]schema.BridgeFlow)
+	}
 	c.feeRecipient = snap.FeeRecipient
 	c.burnProcessor = snap.BurnProcessor
 	return nil
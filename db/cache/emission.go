@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// Emission gets the emission schedule
+func (b *BasicToken) Emission() (schema.Emission, bool) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	if b.emission == nil {
+		return schema.Emission{}, false
+	}
+	return *b.emission, true
+}
+
+// SetEmission replaces the emission schedule and resets its progress, nil removes it
+func (b *BasicToken) SetEmission(emission *schema.Emission) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.emitted = big.NewInt(0)
+	b.lastEmission = 0
+	if emission == nil {
+		b.emission = nil
+		return
+	}
+	newEmission := *emission
+	b.emission = &newEmission
+}
+
+// EmissionProgress returns the scheduled amount already processed and the last emission time
+func (b *BasicToken) EmissionProgress() (*big.Int, int64) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return copyAmount(b.emitted), b.lastEmission
+}
+
+// SetEmissionProgress sets the scheduled amount already processed and the last emission time
+func (b *BasicToken) SetEmissionProgress(emitted *big.Int, lastEmission int64) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.emitted = copyAmount(emitted)
+	b.lastEmission = lastEmission
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyFeeLedger(ledger schema.FeeLedger) schema.FeeLedger {
+	if ledger.Accrued == nil {
+		ledger.Accrued = big.NewInt(0)
+	}
+	if ledger.Withdrawn == nil {
+		ledger.Withdrawn = big.NewInt(0)
+	}
+	return schema.FeeLedger{
+		Accrued:   new(big.Int).Set(ledger.Accrued),
+		Withdrawn: new(big.Int).Set(ledger.Withdrawn),
+	}
+}
+
+// FeeLedgers returns a copy of the fee ledger of every chain type
+func (c *CrossChainToken) FeeLedgers() map[string]schema.FeeLedger {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	result := make(map[string]schema.FeeLedger, len(c.feeLedgers))
+	for chainType, ledger := range c.feeLedgers {
+		result[chainType] = copyFeeLedger(ledger)
+	}
+	return result
+}
+
+// FeeLedger gets the fee ledger of a chain type, empty when no fee accrued
+func (c *CrossChainToken) FeeLedger(chainType string) schema.FeeLedger {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return copyFeeLedger(c.feeLedgers[chainType])
+}
+
+// SetFeeLedger sets the fee ledger of a chain type
+func (c *CrossChainToken) SetFeeLedger(chainType string, ledger schema.FeeLedger) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.feeLedgers == nil {
+		c.feeLedgers = make(map[string]schema.FeeLedger)
+	}
+	c.feeLedgers[chainType] = copyFeeLedger(ledger)
+}
+
+// FeeShares returns the recipients of the fee withdrawals
+func (c *CrossChainToken) FeeShares() []schema.FeeShare {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return append([]schema.FeeShare(nil), c.feeShares...)
+}
+
+// SetFeeShares sets the recipients of the fee withdrawals, empty pays the fee recipient
+func (c *CrossChainToken) SetFeeShares(shares []schema.FeeShare) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.feeShares = append([]schema.FeeShare(nil), shares...)
+}
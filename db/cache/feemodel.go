@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyFeeModel(model schema.FeeModel) schema.FeeModel {
+	model.Tiers = append([]schema.FeeTier(nil), model.Tiers...)
+	return model
+}
+
+func copyFeeModels(models map[string]schema.FeeModel) map[string]schema.FeeModel {
+	result := make(map[string]schema.FeeModel, len(models))
+	for key, model := range models {
+		result[key] = copyFeeModel(model)
+	}
+	return result
+}
+
+// setFeeModel sets the fee model of a key in models, nil removes it
+func setFeeModel(models map[string]schema.FeeModel, key string, model *schema.FeeModel) map[string]schema.FeeModel {
+	if model == nil {
+		delete(models, key)
+		return models
+	}
+	if models == nil {
+		models = make(map[string]schema.FeeModel)
+	}
+	models[key] = copyFeeModel(*model)
+	return models
+}
+
+// GetBurnFeeModels returns a copy of the burn fee models
+func (c *CrossChainToken) GetBurnFeeModels() map[string]schema.FeeModel {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return copyFeeModels(c.burnFeeModels)
+}
+
+// GetBurnFeeModel gets the burn fee model of a chainType or chainType:targetTokenId key
+func (c *CrossChainToken) GetBurnFeeModel(key string) (schema.FeeModel, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	model, exists := c.burnFeeModels[key]
+	return copyFeeModel(model), exists
+}
+
+// SetBurnFeeModel sets the burn fee model of a key, nil removes it
+func (c *CrossChainToken) SetBurnFeeModel(key string, model *schema.FeeModel) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.burnFeeModels = setFeeModel(c.burnFeeModels, key, model)
+}
+
+// GetMintFeeModels returns a copy of the mint fee models
+func (c *CrossChainToken) GetMintFeeModels() map[string]schema.FeeModel {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return copyFeeModels(c.mintFeeModels)
+}
+
+// GetMintFeeModel gets the mint fee model of a chainType or chainType:sourceTokenId key
+func (c *CrossChainToken) GetMintFeeModel(key string) (schema.FeeModel, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	model, exists := c.mintFeeModels[key]
+	return copyFeeModel(model), exists
+}
+
+// SetMintFeeModel sets the mint fee model of a key, nil removes it
+func (c *CrossChainToken) SetMintFeeModel(key string, model *schema.FeeModel) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.mintFeeModels = setFeeModel(c.mintFeeModels, key, model)
+}
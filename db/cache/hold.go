@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyHold(hold schema.Hold) schema.Hold {
+	if hold.Quantity == nil {
+		hold.Quantity = big.NewInt(0)
+	} else {
+		hold.Quantity = new(big.Int).Set(hold.Quantity)
+	}
+	return hold
+}
+
+// GetHold gets a hold by its id
+func (b *BasicToken) GetHold(holdId string) (schema.Hold, bool) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	hold, exists := b.holds[holdId]
+	if !exists {
+		return schema.Hold{}, false
+	}
+	return copyHold(hold), true
+}
+
+// SetHold creates or replaces a hold
+func (b *BasicToken) SetHold(hold schema.Hold) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.holds == nil {
+		b.holds = make(map[string]schema.Hold)
+	}
+	b.holds[hold.Id] = copyHold(hold)
+}
+
+// DeleteHold removes a hold
+func (b *BasicToken) DeleteHold(holdId string) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	delete(b.holds, holdId)
+}
+
+// HoldsOf returns all holds placed on an account, ordered by hold id
+func (b *BasicToken) HoldsOf(accId string) []schema.Hold {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	result := make([]schema.Hold, 0)
+	for _, hold := range b.holds {
+		if hold.Account == accId {
+			result = append(result, copyHold(hold))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result
+}
+
+// HoldAllowance gets the amount a merchant is still allowed to hold on an account
+func (b *BasicToken) HoldAllowance(accId, merchant string) *big.Int {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	amount, exists := b.holdAllowances[accId][merchant]
+	if !exists || amount == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(amount)
+}
+
+// SetHoldAllowance sets the amount a merchant is allowed to hold on an account
+func (b *BasicToken) SetHoldAllowance(accId, merchant string, amount *big.Int) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.holdAllowances == nil {
+		b.holdAllowances = make(map[string]map[string]*big.Int)
+	}
+	if amount == nil || amount.Sign() == 0 {
+		delete(b.holdAllowances[accId], merchant)
+		if len(b.holdAllowances[accId]) == 0 {
+			delete(b.holdAllowances, accId)
+		}
+		return
+	}
+	if b.holdAllowances[accId] == nil {
+		b.holdAllowances[accId] = make(map[string]*big.Int)
+	}
+	b.holdAllowances[accId][merchant] = new(big.Int).Set(amount)
+}
+
+// PruneExpiredHolds removes the holds expired at now and returns how many were removed
+func (b *BasicToken) PruneExpiredHolds(now int64) int {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	pruned := 0
+	for holdId, hold := range b.holds {
+		if hold.ExpiresAt > 0 && hold.ExpiresAt <= now {
+			delete(b.holds, holdId)
+			pruned++
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func idempotencyKey(sender, key string) string {
+	return sender + ":" + key
+}
+
+// IdempotencyRecord gets the record of an idempotency key of a sender
+func (b *BasicToken) IdempotencyRecord(sender, key string) (schema.IdempotencyRecord, bool) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	record, exists := b.idempotencyRecords[idempotencyKey(sender, key)]
+	return record, exists
+}
+
+// AddIdempotencyRecord stores a record, records are kept in insertion order
+func (b *BasicToken) AddIdempotencyRecord(record schema.IdempotencyRecord) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.idempotencyRecords == nil {
+		b.idempotencyRecords = make(map[string]schema.IdempotencyRecord)
+	}
+	if b.idempotencyCounts == nil {
+		b.idempotencyCounts = make(map[string]int)
+	}
+	key := idempotencyKey(record.Sender, record.Key)
+	if _, exists := b.idempotencyRecords[key]; !exists {
+		b.idempotencyOrder = append(b.idempotencyOrder, key)
+		b.idempotencyCounts[record.Sender]++
+	}
+	b.idempotencyRecords[key] = record
+}
+
+// IdempotencyKeyCount returns the number of records of a sender
+func (b *BasicToken) IdempotencyKeyCount(sender string) int {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return b.idempotencyCounts[sender]
+}
+
+// IdempotencyKeyTotal returns the number of records of every sender
+func (b *BasicToken) IdempotencyKeyTotal() int {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return len(b.idempotencyRecords)
+}
+
+// PruneIdempotencyRecords drops the expired records
+func (b *BasicToken) PruneIdempotencyRecords(expiredBefore int64) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	drop := 0
+	for _, key := range b.idempotencyOrder {
+		record := b.idempotencyRecords[key]
+		if record.Timestamp >= expiredBefore {
+			break
+		}
+		delete(b.idempotencyRecords, key)
+		if b.idempotencyCounts[record.Sender]--; b.idempotencyCounts[record.Sender] <= 0 {
+			delete(b.idempotencyCounts, record.Sender)
+		}
+		drop++
+	}
+	b.idempotencyOrder = b.idempotencyOrder[drop:]
+}
+
+// idempotencySnapshot returns the records in insertion order, the caller must hold the lock
+func (b *BasicToken) idempotencySnapshot() []schema.IdempotencyRecord {
+	records := make([]schema.IdempotencyRecord, 0, len(b.idempotencyOrder))
+	for _, key := range b.idempotencyOrder {
+		records = append(records, b.idempotencyRecords[key])
+	}
+	return records
+}
+
+// restoreIdempotency rebuilds the records from a snapshot, the caller must hold the lock
+func (b *BasicToken) restoreIdempotency(records []schema.IdempotencyRecord) {
+	b.idempotencyRecords = make(map[string]schema.IdempotencyRecord, len(records))
+	b.idempotencyOrder = make([]string, 0, len(records))
+	b.idempotencyCounts = make(map[string]int)
+	for _, record := range records {
+		key := idempotencyKey(record.Sender, record.Key)
+		b.idempotencyRecords[key] = record
+		b.idempotencyOrder = append(b.idempotencyOrder, key)
+		b.idempotencyCounts[record.Sender]++
+	}
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"maps"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// HaltedChains returns a copy of the invariant alerts halting chain types
+func (c *CrossChainToken) HaltedChains() map[string]schema.InvariantAlert {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return maps.Clone(c.haltedChains)
+}
+
+// HaltedChain gets the invariant alert halting a chain type
+func (c *CrossChainToken) HaltedChain(chainType string) (schema.InvariantAlert, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	alert, halted := c.haltedChains[chainType]
+	return alert, halted
+}
+
+// SetHaltedChain halts the chain type of an invariant alert
+func (c *CrossChainToken) SetHaltedChain(alert schema.InvariantAlert) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.haltedChains == nil {
+		c.haltedChains = make(map[string]schema.InvariantAlert)
+	}
+	c.haltedChains[alert.ChainType] = alert
+}
+
+// DeleteHaltedChain resumes a halted chain type
+func (c *CrossChainToken) DeleteHaltedChain(chainType string) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	delete(c.haltedChains, chainType)
+}
@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// AppendLockAuditRecord appends a record to the lock amount audit log
+func (c *CrossChainToken) AppendLockAuditRecord(record schema.LockAuditRecord) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.lockAuditLog = append(c.lockAuditLog, record)
+}
+
+// LockAuditRecords returns a copy of the lock amount audit log
+func (c *CrossChainToken) LockAuditRecords() []schema.LockAuditRecord {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	result := make([]schema.LockAuditRecord, len(c.lockAuditLog))
+	copy(result, c.lockAuditLog)
+	return result
+}
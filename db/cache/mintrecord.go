@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// GetMintedRecord gets the record of a mint transaction hash
+func (c *CrossChainToken) GetMintedRecord(mintTxHash string) (schema.MintRecord, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	record, exists := c.mintedRecords[mintTxHash]
+	return record, exists
+}
+
+// AddMintedRecord stores the record of a mint transaction hash, records are kept in insertion order
+func (c *CrossChainToken) AddMintedRecord(record schema.MintRecord) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.mintedRecords == nil {
+		c.mintedRecords = make(map[string]schema.MintRecord)
+	}
+	if _, exists := c.mintedRecords[record.MintTxHash]; !exists {
+		c.mintOrder = append(c.mintOrder, record.MintTxHash)
+	}
+	c.mintedRecords[record.MintTxHash] = record
+
+	if c.mintLatestHeights == nil {
+		c.mintLatestHeights = make(map[string]uint64)
+	}
+	if record.BlockHeight > c.mintLatestHeights[record.SourceChainType] {
+		c.mintLatestHeights[record.SourceChainType] = record.BlockHeight
+	}
+}
+
+// MintRetention gets the retention of the mint records
+func (c *CrossChainToken) MintRetention() schema.MintRetention {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return c.mintRetention
+}
+
+// SetMintRetention sets the retention of the mint records
+func (c *CrossChainToken) SetMintRetention(retention schema.MintRetention) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.mintRetention = retention
+}
+
+// MintWatermark gets the block height at or below which mints of a chain are rejected
+func (c *CrossChainToken) MintWatermark(chainType string) uint64 {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return c.mintWatermark(chainType)
+}
+
+// mintWatermark must be called with the lock held
+func (c *CrossChainToken) mintWatermark(chainType string) uint64 {
+	watermark := c.mintWatermarks[chainType]
+	maxBlocks := c.mintRetention.MaxBlocks
+	if latest := c.mintLatestHeights[chainType]; maxBlocks > 0 && latest > maxBlocks {
+		watermark = max(watermark, latest-maxBlocks)
+	}
+	return watermark
+}
+
+// ResetMintWatermark lowers the highest seen block height and the watermark of a chain to a block height,
+// e.g. after a mistyped X-BlockHeight. Records and archived hashes above it are kept at the block height,
+// so the watermark still compacts them and their replays stay rejected.
+func (c *CrossChainToken) ResetMintWatermark(chainType string, blockHeight uint64) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.mintLatestHeights[chainType] > blockHeight {
+		c.mintLatestHeights[chainType] = blockHeight
+	}
+	if c.mintWatermarks[chainType] > blockHeight {
+		c.mintWatermarks[chainType] = blockHeight
+	}
+	for mintTxHash, record := range c.mintedRecords {
+		if record.SourceChainType == chainType && record.BlockHeight > blockHeight {
+			record.BlockHeight = blockHeight
+			c.mintedRecords[mintTxHash] = record
+		}
+	}
+	for mintTxHash, height := range c.mintArchive[chainType] {
+		if height > blockHeight {
+			c.mintArchive[chainType][mintTxHash] = blockHeight
+		}
+	}
+}
+
+// MintArchived reports whether a mint tx hash was minted and its record pruned by age
+func (c *CrossChainToken) MintArchived(mintTxHash string) bool {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	for _, archive := range c.mintArchive {
+		if _, exists := archive[mintTxHash]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// CanonicalizeMintTxHashes re-keys the minted records, the archive and the pending attestations by
+// the canonical form of their tx hash, so hashes stored before their normalization are still found.
+// The first record of a canonical hash is kept.
+func (c *CrossChainToken) CanonicalizeMintTxHashes(canonical func(chainType, mintTxHash string) string) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	records := make(map[string]schema.MintRecord, len(c.mintedRecords))
+	order := make([]string, 0, len(c.mintOrder))
+	for _, mintTxHash := range c.mintOrder {
+		record := c.mintedRecords[mintTxHash]
+		record.MintTxHash = canonical(record.SourceChainType, mintTxHash)
+		if _, exists := records[record.MintTxHash]; exists {
+			continue
+		}
+		records[record.MintTxHash] = record
+		order = append(order, record.MintTxHash)
+	}
+	c.mintedRecords, c.mintOrder = records, order
+
+	for chainType, archive := range c.mintArchive {
+		canonicalArchive := make(map[string]uint64, len(archive))
+		for mintTxHash, blockHeight := range archive {
+			canonicalHash := canonical(chainType, mintTxHash)
+			canonicalArchive[canonicalHash] = max(canonicalArchive[canonicalHash], blockHeight)
+		}
+		c.mintArchive[chainType] = canonicalArchive
+	}
+
+	attestations := make(map[string]schema.MintAttestation, len(c.mintAttestations))
+	for mintTxHash, attestation := range c.mintAttestations {
+		attestation.MintTxHash = canonical(attestation.SourceChainType, mintTxHash)
+		attestations[attestation.MintTxHash] = attestation
+	}
+	c.mintAttestations = attestations
+}
+
+// PruneMintedRecords drops the records at or below the block watermark, their heights are compacted
+// into the per-chain watermarks. Mints arrive out of block order, so records older than MaxAge only
+// keep their tx hash in the archive, until the block watermark covers them.
+func (c *CrossChainToken) PruneMintedRecords(now int64) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.mintWatermarks == nil {
+		c.mintWatermarks = make(map[string]uint64)
+	}
+	if c.mintArchive == nil {
+		c.mintArchive = make(map[string]map[string]uint64)
+	}
+
+	maxAge := c.mintRetention.MaxAge
+	order := c.mintOrder[:0]
+	for _, mintTxHash := range c.mintOrder {
+		record := c.mintedRecords[mintTxHash]
+		switch {
+		case record.BlockHeight > 0 && record.BlockHeight <= c.mintWatermark(record.SourceChainType):
+			if record.BlockHeight > c.mintWatermarks[record.SourceChainType] {
+				c.mintWatermarks[record.SourceChainType] = record.BlockHeight
+			}
+		case maxAge > 0 && record.Timestamp > 0 && record.Timestamp < now-maxAge:
+			if c.mintArchive[record.SourceChainType] == nil {
+				c.mintArchive[record.SourceChainType] = make(map[string]uint64)
+			}
+			c.mintArchive[record.SourceChainType][mintTxHash] = record.BlockHeight
+		default:
+			order = append(order, mintTxHash)
+			continue
+		}
+		delete(c.mintedRecords, mintTxHash)
+	}
+	c.mintOrder = order
+
+	for chainType, archive := range c.mintArchive {
+		watermark := c.mintWatermark(chainType)
+		for mintTxHash, blockHeight := range archive {
+			if blockHeight > 0 && blockHeight <= watermark {
+				delete(archive, mintTxHash)
+			}
+		}
+		if len(archive) == 0 {
+			delete(c.mintArchive, chainType)
+		}
+	}
+}
+
+// MintRecords returns the records of a chain, or of all chains when empty, in insertion order
+func (c *CrossChainToken) MintRecords(chainType string) []schema.MintRecord {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	records := make([]schema.MintRecord, 0)
+	for _, mintTxHash := range c.mintOrder {
+		if record := c.mintedRecords[mintTxHash]; chainType == "" || record.SourceChainType == chainType {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// mintSnapshot returns the records in insertion order, the caller must hold the lock
+func (c *CrossChainToken) mintSnapshot() []schema.MintRecord {
+	records := make([]schema.MintRecord, 0, len(c.mintOrder))
+	for _, mintTxHash := range c.mintOrder {
+		records = append(records, c.mintedRecords[mintTxHash])
+	}
+	return records
+}
+
+// restoreMintRecords rebuilds the records from a snapshot, records of the legacy
+// hash to chain type store are kept without timestamp. The caller must hold the lock.
+func (c *CrossChainToken) restoreMintRecords(records []schema.MintRecord, legacy map[string]string) {
+	c.mintedRecords = make(map[string]schema.MintRecord, len(records)+len(legacy))
+	c.mintOrder = make([]string, 0, len(records)+len(legacy))
+	for mintTxHash, chainType := range legacy {
+		records = append(records, schema.MintRecord{MintTxHash: mintTxHash, SourceChainType: chainType})
+	}
+	for _, record := range records {
+		if _, exists := c.mintedRecords[record.MintTxHash]; !exists {
+			c.mintOrder = append(c.mintOrder, record.MintTxHash)
+		}
+		c.mintedRecords[record.MintTxHash] = record
+	}
+}
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"encoding/json"
+	"math/big"
+
+	dbSchema "github.com/aox-labs/hymx-vmtoken/db/cache/schema"
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+)
+
+// RebaseToken keeps shares in the balances of the embedded BasicToken
+// and converts them to amounts with the rebase index
+type RebaseToken struct {
+	*BasicToken
+
+	index   *big.Int
+	rebaser string
+}
+
+func NewRebaseToken(basicToken *BasicToken, index *big.Int, rebaser string) *RebaseToken {
+	// Shares are derived from the balances, not from the recorded total supply
+	basicToken.totalSupply = big.NewInt(0)
+	for accId, balance := range basicToken.balances {
+		shares := new(big.Int).Mul(balance, schema.RebaseSharePrecision)
+		basicToken.balances[accId] = shares
+		basicToken.totalSupply.Add(basicToken.totalSupply, shares)
+	}
+	return &RebaseToken{
+		BasicToken: basicToken,
+		index:      new(big.Int).Set(index),
+		rebaser:    rebaser,
+	}
+}
+
+// shareUnit is the number of shares per unit at an index of one
+var shareUnit = new(big.Int).Mul(schema.RebaseIndexPrecision, schema.RebaseSharePrecision)
+
+// toAmount converts shares to amount rounding down, the caller must hold the lock
+func (r *RebaseToken) toAmount(shares *big.Int) *big.Int {
+	if shares == nil {
+		return big.NewInt(0)
+	}
+	amount := new(big.Int).Mul(shares, r.index)
+	return amount.Quo(amount, shareUnit)
+}
+
+// toShares converts amount to shares rounding up, the caller must hold the lock.
+// As long as one share is worth less than one unit, the shares convert back to exactly the amount,
+// so balance changes move the same amount out of one account and into another.
+func (r *RebaseToken) toShares(amount *big.Int) *big.Int {
+	if amount == nil || amount.Sign() <= 0 || r.index.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	shares := new(big.Int).Mul(amount, shareUnit)
+	shares.Add(shares, new(big.Int).Sub(r.index, big.NewInt(1)))
+	return shares.Quo(shares, r.index)
+}
+
+func (r *RebaseToken) Index() *big.Int {
+	r.rwlock.RLock()
+	defer r.rwlock.RUnlock()
+	return new(big.Int).Set(r.index)
+}
+
+func (r *RebaseToken) SetIndex(index *big.Int) {
+	r.rwlock.Lock()
+	defer r.rwlock.Unlock()
+	r.index = new(big.Int).Set(index)
+}
+
+func (r *RebaseToken) Rebaser() string {
+	r.rwlock.RLock()
+	defer r.rwlock.RUnlock()
+	return r.rebaser
+}
+
+func (r *RebaseToken) SetRebaser(addr string) {
+	r.rwlock.Lock()
+	defer r.rwlock.Unlock()
+	r.rebaser = addr
+}
+
+// GetTotalSupply returns the rebased amount of all shares
+func (r *RebaseToken) GetTotalSupply() *big.Int {
+	r.rwlock.RLock()
+	defer r.rwlock.RUnlock()
+	return r.toAmount(r.totalSupply)
+}
+
+// SetTotalSupply is a no-op, the total supply is derived from the sum of shares kept by UpdateBalance
+func (r *RebaseToken) SetTotalSupply(*big.Int) {}
+
+func (r *RebaseToken) BalanceOf(accId string) (*big.Int, error) {
+	_, accId, err := utils.IDCheck(accId)
+	if err != nil {
+		return nil, err
+	}
+	r.rwlock.RLock()
+	defer r.rwlock.RUnlock()
+	return r.toAmount(r.balances[accId]), nil
+}
+
+func (r *RebaseToken) Balances() (map[string]*big.Int, error) {
+	r.rwlock.RLock()
+	defer r.rwlock.RUnlock()
+	result := make(map[string]*big.Int, len(r.balances))
+	for k, v := range r.balances {
+		result[k] = r.toAmount(v)
+	}
+	return result, nil
+}
+
+func (r *RebaseToken) UpdateBalance(accId string, amount *big.Int) error {
+	_, accId, err := utils.IDCheck(accId)
+	if err != nil {
+		return err
+	}
+	r.rwlock.Lock()
+	defer r.rwlock.Unlock()
+	if r.balances == nil {
+		r.balances = make(map[string]*big.Int)
+	}
+	if r.totalSupply == nil {
+		r.totalSupply = big.NewInt(0)
+	}
+	if oldShares, exists := r.balances[accId]; exists && oldShares != nil {
+		r.totalSupply = new(big.Int).Sub(r.totalSupply, oldShares)
+	}
+	shares := r.toShares(amount)
+	if shares.Sign() == 0 {
+		delete(r.balances, accId)
+		return nil
+	}
+	r.balances[accId] = shares
+	r.totalSupply = new(big.Int).Add(r.totalSupply, shares)
+	return nil
+}
+
+// Checkpoint creates a snapshot with the shares and the index
+func (r *RebaseToken) Checkpoint() (data string, err error) {
+	basicCheckpoint, err := r.BasicToken.Checkpoint()
+	if err != nil {
+		return "", err
+	}
+	r.rwlock.RLock()
+	defer r.rwlock.RUnlock()
+	snap := dbSchema.RebaseSnapshot{
+		Basic:          basicCheckpoint,
+		Index:          r.index,
+		Rebaser:        r.rebaser,
+		SharePrecision: schema.RebaseSharePrecision,
+	}
+	by, err := json.Marshal(snap)
+	if err != nil {
+		return "", err
+	}
+	return string(by), nil
+}
+
+// Restore restores the shares and the index from a snapshot
+func (r *RebaseToken) Restore(data string) error {
+	snap := &dbSchema.RebaseSnapshot{}
+	if err := json.Unmarshal([]byte(data), snap); err != nil {
+		return err
+	}
+	if err := r.BasicToken.Restore(snap.Basic); err != nil {
+		return err
+	}
+	r.rwlock.Lock()
+	defer r.rwlock.Unlock()
+	r.index = snap.Index
+	if r.index == nil {
+		r.index = new(big.Int).Set(schema.RebaseIndexPrecision)
+	}
+	r.rebaser = snap.Rebaser
+
+	// Snapshots taken before the share precision hold one share per unit
+	if snap.SharePrecision == nil {
+		for accId, shares := range r.balances {
+			r.balances[accId] = new(big.Int).Mul(shares, schema.RebaseSharePrecision)
+		}
+		r.totalSupply = new(big.Int).Mul(r.totalSupply, schema.RebaseSharePrecision)
+	}
+	return nil
+}
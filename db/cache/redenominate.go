@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func scaleAmount(amount, numerator, denominator *big.Int) *big.Int {
+	if amount == nil {
+		return big.NewInt(0)
+	}
+	result := new(big.Int).Mul(amount, numerator)
+	return result.Quo(result, denominator)
+}
+
+func scaleAmountStr(amount string, numerator, denominator *big.Int) string {
+	amt, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return amount
+	}
+	return scaleAmount(amt, numerator, denominator).String()
+}
+
+// Redenominate scales all balances and amounts, see schema.BasicDB
+func (b *BasicToken) Redenominate(numerator, denominator *big.Int) (dust *big.Int, balances map[string]*big.Int) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+
+	// Round every balance down, the total supply is the sum of the new balances
+	newTotalSupply := big.NewInt(0)
+	balances = make(map[string]*big.Int, len(b.balances))
+	for accId, balance := range b.balances {
+		newBalance := scaleAmount(balance, numerator, denominator)
+		if newBalance.Sign() == 0 {
+			delete(b.balances, accId)
+			continue
+		}
+		b.balances[accId] = newBalance
+		balances[accId] = new(big.Int).Set(newBalance)
+		newTotalSupply.Add(newTotalSupply, newBalance)
+	}
+	dust = new(big.Int).Sub(scaleAmount(b.totalSupply, numerator, denominator), newTotalSupply)
+	b.totalSupply = newTotalSupply
+
+	b.scaleAmounts(numerator, denominator)
+	return dust, balances
+}
+
+// scaleAmounts scales every amount besides the balances, the caller must hold the lock
+func (b *BasicToken) scaleAmounts(numerator, denominator *big.Int) {
+	if b.maxSupply != nil && b.maxSupply.Sign() > 0 {
+		b.maxSupply = scaleAmount(b.maxSupply, numerator, denominator)
+	}
+
+	for holdId, hold := range b.holds {
+		hold.Quantity = scaleAmount(hold.Quantity, numerator, denominator)
+		b.holds[holdId] = hold
+	}
+
+	for accId, merchants := range b.holdAllowances {
+		for merchant, amount := range merchants {
+			merchants[merchant] = scaleAmount(amount, numerator, denominator)
+		}
+		b.holdAllowances[accId] = merchants
+	}
+
+	if b.policy.MaxTransferAmount != "" {
+		b.policy.MaxTransferAmount = scaleAmountStr(b.policy.MaxTransferAmount, numerator, denominator)
+	}
+	if b.policy.MaxBalance != "" {
+		b.policy.MaxBalance = scaleAmountStr(b.policy.MaxBalance, numerator, denominator)
+	}
+
+	b.scaleRewards(numerator, denominator)
+	b.scaleTwabs(numerator, denominator)
+
+	if b.emission != nil {
+		b.emission.Rate = scaleAmountStr(b.emission.Rate, numerator, denominator)
+		b.emitted = scaleAmount(b.emitted, numerator, denominator)
+	}
+}
+
+// RedenominateMultisig returns the signers that have to approve a redenomination and the approval threshold
+func (b *BasicToken) RedenominateMultisig() (signers []string, threshold int) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return append([]string(nil), b.redenominateSigners...), b.redenominateThreshold
+}
+
+// SetRedenominateMultisig sets the redenomination signers, with a zero threshold the owner redenominates alone
+func (b *BasicToken) SetRedenominateMultisig(signers []string, threshold int) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.redenominateSigners = append([]string(nil), signers...)
+	b.redenominateThreshold = threshold
+}
+
+// PendingRedenomination returns the redenomination waiting for approvals
+func (b *BasicToken) PendingRedenomination() (schema.Redenomination, bool) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	if b.pendingRedenomination == nil {
+		return schema.Redenomination{}, false
+	}
+	proposal := *b.pendingRedenomination
+	proposal.Approvals = append([]string(nil), proposal.Approvals...)
+	return proposal, true
+}
+
+// SetPendingRedenomination replaces the redenomination waiting for approvals, nil clears it
+func (b *BasicToken) SetPendingRedenomination(proposal *schema.Redenomination) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if proposal == nil {
+		b.pendingRedenomination = nil
+		return
+	}
+	pending := *proposal
+	pending.Approvals = append([]string(nil), proposal.Approvals...)
+	b.pendingRedenomination = &pending
+}
+
+// Redenominate scales the index instead of the shares, so no balance is rounded
+func (r *RebaseToken) Redenominate(numerator, denominator *big.Int) (dust *big.Int, balances map[string]*big.Int) {
+	r.rwlock.Lock()
+	defer r.rwlock.Unlock()
+
+	oldTotalSupply := r.toAmount(r.totalSupply)
+	r.index = scaleAmount(r.index, numerator, denominator)
+	r.scaleAmounts(numerator, denominator)
+	balances = make(map[string]*big.Int, len(r.balances))
+	for accId, shares := range r.balances {
+		balances[accId] = r.toAmount(shares)
+	}
+	return new(big.Int).Sub(scaleAmount(oldTotalSupply, numerator, denominator), r.toAmount(r.totalSupply)), balances
+}
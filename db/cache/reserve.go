@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"maps"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// ReserveAttestors returns the accounts that can send reserve reports
+func (c *CrossChainToken) ReserveAttestors() []string {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return append([]string(nil), c.reserveAttestors...)
+}
+
+// SetReserveAttestors sets the accounts that can send reserve reports
+func (c *CrossChainToken) SetReserveAttestors(attestors []string) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.reserveAttestors = append([]string(nil), attestors...)
+}
+
+// ReserveTolerance returns the reserve shortfall tolerance in basis points
+func (c *CrossChainToken) ReserveTolerance() int64 {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return c.reserveTolerance
+}
+
+// SetReserveTolerance sets the reserve shortfall tolerance in basis points
+func (c *CrossChainToken) SetReserveTolerance(tolerance int64) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.reserveTolerance = tolerance
+}
+
+// ReserveReports returns a copy of the latest reserve report of every source token
+func (c *CrossChainToken) ReserveReports() map[string]schema.ReserveReport {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return maps.Clone(c.reserveReports)
+}
+
+// ReserveReport gets the latest reserve report of a source token
+func (c *CrossChainToken) ReserveReport(tokenId string) (schema.ReserveReport, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	report, exists := c.reserveReports[tokenId]
+	return report, exists
+}
+
+// SetReserveReport stores the latest reserve report of a source token
+func (c *CrossChainToken) SetReserveReport(report schema.ReserveReport) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.reserveReports == nil {
+		c.reserveReports = make(map[string]schema.ReserveReport)
+	}
+	c.reserveReports[report.SourceTokenId] = report
+}
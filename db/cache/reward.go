@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyRewardAccount(account schema.RewardAccount) schema.RewardAccount {
+	if account.Paid == nil {
+		account.Paid = big.NewInt(0)
+	} else {
+		account.Paid = new(big.Int).Set(account.Paid)
+	}
+	if account.Owed == nil {
+		account.Owed = big.NewInt(0)
+	} else {
+		account.Owed = new(big.Int).Set(account.Owed)
+	}
+	return account
+}
+
+// RewardTokens returns every token that has been distributed, ordered by id
+func (b *BasicToken) RewardTokens() []string {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	result := make([]string, 0, len(b.rewardPerToken))
+	for rewardToken := range b.rewardPerToken {
+		result = append(result, rewardToken)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// RewardPerToken gets the cumulative reward per token of a reward token
+func (b *BasicToken) RewardPerToken(rewardToken string) *big.Int {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	rewardPerToken, exists := b.rewardPerToken[rewardToken]
+	if !exists || rewardPerToken == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(rewardPerToken)
+}
+
+// SetRewardPerToken sets the cumulative reward per token of a reward token
+func (b *BasicToken) SetRewardPerToken(rewardToken string, rewardPerToken *big.Int) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.rewardPerToken == nil {
+		b.rewardPerToken = make(map[string]*big.Int)
+	}
+	b.rewardPerToken[rewardToken] = new(big.Int).Set(rewardPerToken)
+}
+
+// RewardAccount gets the reward state of an account, zero if the account has never been settled
+func (b *BasicToken) RewardAccount(accId, rewardToken string) schema.RewardAccount {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return copyRewardAccount(b.rewardAccounts[accId][rewardToken])
+}
+
+// SetRewardAccount sets the reward state of an account
+func (b *BasicToken) SetRewardAccount(accId, rewardToken string, account schema.RewardAccount) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.rewardAccounts == nil {
+		b.rewardAccounts = make(map[string]map[string]schema.RewardAccount)
+	}
+	if b.rewardAccounts[accId] == nil {
+		b.rewardAccounts[accId] = make(map[string]schema.RewardAccount)
+	}
+	b.rewardAccounts[accId][rewardToken] = copyRewardAccount(account)
+}
+
+// RewardExclusions returns the accounts that don't earn rewards
+func (b *BasicToken) RewardExclusions() []string {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return append([]string(nil), b.rewardExclusions...)
+}
+
+// SetRewardExclusions replaces the accounts that don't earn rewards
+func (b *BasicToken) SetRewardExclusions(accIds []string) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.rewardExclusions = append([]string(nil), accIds...)
+}
+
+// AllowedRewardTokens returns the other tokens that may be distributed through a Credit-Notice
+func (b *BasicToken) AllowedRewardTokens() []string {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	return append([]string(nil), b.allowedRewardTokens...)
+}
+
+// SetAllowedRewardTokens replaces the other tokens that may be distributed through a Credit-Notice
+func (b *BasicToken) SetAllowedRewardTokens(rewardTokens []string) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	b.allowedRewardTokens = append([]string(nil), rewardTokens...)
+}
+
+// scaleRewards scales the unclaimed rewards paid in the token itself, the caller must hold the lock
+func (b *BasicToken) scaleRewards(numerator, denominator *big.Int) {
+	for _, accounts := range b.rewardAccounts {
+		account, exists := accounts[b.info.Id]
+		if !exists {
+			continue
+		}
+		account.Owed = scaleAmount(account.Owed, numerator, denominator)
+		accounts[b.info.Id] = account
+	}
+}
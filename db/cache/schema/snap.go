@@ -1,6 +1,10 @@
 package schema
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
 
 // BasicSnapshot represents a snapshot of a basic token for checkpoint/restore
 type BasicSnapshot struct {
@@ -15,14 +19,75 @@ type BasicSnapshot struct {
 	Owner       string              `json:"owner"`
 	MintOwner   string              `json:"mintOwner"`
 	MaxSupply   *big.Int            `json:"maxSupply"`
+
+	Holds          map[string]schema.Hold         `json:"holds"`          // key: holdId
+	HoldAllowances map[string]map[string]*big.Int `json:"holdAllowances"` // key: accId, merchant
+
+	ComplianceOwner string               `json:"complianceOwner"`
+	Frozen          map[string]bool      `json:"frozen"` // key: accId
+	AuditLog        []schema.AuditRecord `json:"auditLog"`
+
+	Policy schema.Policy `json:"policy"`
+
+	RedenominateSigners   []string               `json:"redenominateSigners"`
+	RedenominateThreshold int                    `json:"redenominateThreshold"`
+	PendingRedenomination *schema.Redenomination `json:"pendingRedenomination"`
+
+	RewardPerToken      map[string]*big.Int                        `json:"rewardPerToken"` // key: rewardToken
+	RewardAccounts      map[string]map[string]schema.RewardAccount `json:"rewardAccounts"` // key: accId, rewardToken
+	RewardExclusions    []string                                   `json:"rewardExclusions"`
+	AllowedRewardTokens []string                                   `json:"allowedRewardTokens"`
+
+	Twabs           map[string]schema.Twab `json:"twabs"` // key: accId
+	TotalSupplyTwab *schema.Twab           `json:"totalSupplyTwab"`
+
+	Emission     *schema.Emission `json:"emission"`
+	Emitted      *big.Int         `json:"emitted"`
+	LastEmission int64            `json:"lastEmission"`
+
+	IdempotencyRecords []schema.IdempotencyRecord `json:"idempotencyRecords"` // in insertion order
 }
 
 // CrossChainMultiSnapshot represents a snapshot of a cross-chain multi token for checkpoint/restore
 type CrossChainMultiSnapshot struct {
-	MintedRecords     map[string]string   `json:"mintedRecords"`     // key: X-MintTxHash val: chainType
-	SourceTokenChains map[string]string   `json:"sourceTokenChains"` // key: sourceTokenId, val: sourceChainType
-	SourceLockAmounts map[string]*big.Int `json:"sourceLockAmounts"` // key: sourceChain:sourceTokenId, val: source chain locked amount
-	BurnFees          map[string]*big.Int `json:"burnFees"`          // key: chainType, val: burn fee
-	FeeRecipient      string              `json:"feeRecipient"`
-	BurnProcessor     string              `json:"burnProcessor"`
+	MintedRecords     map[string]string                 `json:"mintedRecords,omitempty"` // legacy store read on restore, key: X-MintTxHash val: chainType
+	MintRecords       []schema.MintRecord               `json:"mintRecords"`             // in insertion order
+	MintRetention     schema.MintRetention              `json:"mintRetention"`
+	MintLatestHeights map[string]uint64                 `json:"mintLatestHeights"` // key: chainType, val: highest seen block height
+	MintWatermarks    map[string]uint64                 `json:"mintWatermarks"`    // key: chainType, val: highest pruned block height
+	MintArchive       map[string]map[string]uint64      `json:"mintArchive"`       // key: chainType, X-MintTxHash pruned by age, val: block height
+	BurnRecords       []schema.BurnRecord               `json:"burnRecords"`       // in insertion order
+	BurnRecordMaxAge  int64                             `json:"burnRecordMaxAge"`
+	SourceTokenChains map[string]string                 `json:"sourceTokenChains,omitempty"` // legacy registry read on restore, key: sourceTokenId, val: sourceChainType
+	SourceTokens      map[string]schema.SourceToken     `json:"sourceTokens"`                // key: sourceTokenId
+	BridgeAdmin       string                            `json:"bridgeAdmin"`
+	DustPolicy        string                            `json:"dustPolicy"`
+	Relayers          []string                          `json:"relayers"`
+	RelayerThreshold  int                               `json:"relayerThreshold"`
+	MintAttestations  map[string]schema.MintAttestation `json:"mintAttestations"`  // key: X-MintTxHash
+	SourceLockAmounts map[string]*big.Int               `json:"sourceLockAmounts"` // key: sourceChain:sourceTokenId, val: source chain locked amount
+	BurnFees          map[string]*big.Int               `json:"burnFees"`          // key: chainType, val: burn fee
+	BurnFeeModels     map[string]schema.FeeModel        `json:"burnFeeModels"`     // key: chainType or chainType:targetTokenId
+	MintFeeModels     map[string]schema.FeeModel        `json:"mintFeeModels"`     // key: chainType or chainType:sourceTokenId
+	FeeLedgers        map[string]schema.FeeLedger       `json:"feeLedgers"`        // key: chainType
+	FeeShares         []schema.FeeShare                 `json:"feeShares"`
+	BurnRoutes        map[string]schema.BurnRoute       `json:"burnRoutes"` // key: chainType
+	LockAuditLog      []schema.LockAuditRecord          `json:"lockAuditLog"`
+	ReserveAttestors  []string                          `json:"reserveAttestors"`
+	ReserveTolerance  int64                             `json:"reserveTolerance"` // basis points
+	ReserveReports    map[string]schema.ReserveReport   `json:"reserveReports"`   // key: sourceTokenId
+	HaltedChains      map[string]schema.InvariantAlert  `json:"haltedChains"`     // key: chainType
+	BridgeLimits      map[string]schema.BridgeLimit     `json:"bridgeLimits"`     // key: chainType or chainType:tokenId
+	BridgeFlows       map[string]schema.BridgeFlow      `json:"bridgeFlows"`      // key: chainType or chainType:tokenId
+	FeeRecipient      string                            `json:"feeRecipient"`
+	BurnProcessor     string                            `json:"burnProcessor"`
+}
+
+// RebaseSnapshot represents a snapshot of a rebasing token for checkpoint/restore
+type RebaseSnapshot struct {
+	Basic   string   `json:"basic"` // BasicSnapshot with shares as balances and total supply
+	Index   *big.Int `json:"index"`
+	Rebaser string   `json:"rebaser"`
+	// SharePrecision is the shares per unit at the unit index, snapshots without it hold one share per unit
+	SharePrecision *big.Int `json:"sharePrecision,omitempty"`
 }
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+// GetSourceTokenChains returns the chain type of every registered source token
+func (c *CrossChainToken) GetSourceTokenChains() map[string]string {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	result := make(map[string]string, len(c.sourceTokens))
+	for tokenId, sourceToken := range c.sourceTokens {
+		result[tokenId] = sourceToken.ChainType
+	}
+	return result
+}
+
+// GetSourceTokenChain gets the chain type for a source token ID
+func (c *CrossChainToken) GetSourceTokenChain(tokenId string) (string, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	sourceToken, exists := c.sourceTokens[tokenId]
+	return sourceToken.ChainType, exists
+}
+
+// GetSourceTokens returns a copy of the source token registry
+func (c *CrossChainToken) GetSourceTokens() map[string]schema.SourceToken {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	result := make(map[string]schema.SourceToken, len(c.sourceTokens))
+	for tokenId, sourceToken := range c.sourceTokens {
+		result[tokenId] = sourceToken
+	}
+	return result
+}
+
+// GetSourceToken gets a registered source token
+func (c *CrossChainToken) GetSourceToken(tokenId string) (schema.SourceToken, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	sourceToken, exists := c.sourceTokens[tokenId]
+	return sourceToken, exists
+}
+
+// SetSourceToken registers or updates a source token
+func (c *CrossChainToken) SetSourceToken(sourceToken schema.SourceToken) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	if c.sourceTokens == nil {
+		c.sourceTokens = make(map[string]schema.SourceToken)
+	}
+	c.sourceTokens[sourceToken.TokenId] = sourceToken
+}
+
+// GetBridgeAdmin gets the bridge admin address
+func (c *CrossChainToken) GetBridgeAdmin() string {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	return c.bridgeAdmin
+}
+
+// SetBridgeAdmin sets the bridge admin address
+func (c *CrossChainToken) SetBridgeAdmin(addr string) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+	c.bridgeAdmin = addr
+}
+
+// restoreSourceTokens rebuilds the registry from a snapshot, the source tokens of the legacy
+// token to chain type store are registered without metadata. The caller must hold the lock.
+func (c *CrossChainToken) restoreSourceTokens(sourceTokens map[string]schema.SourceToken, legacy map[string]string) {
+	c.sourceTokens = sourceTokens
+	if c.sourceTokens == nil {
+		c.sourceTokens = make(map[string]schema.SourceToken, len(legacy))
+	}
+	for tokenId, chainType := range legacy {
+		if _, exists := c.sourceTokens[tokenId]; !exists {
+			c.sourceTokens[tokenId] = schema.SourceToken{TokenId: tokenId, ChainType: chainType}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func copyAmount(amount *big.Int) *big.Int {
+	if amount == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(amount)
+}
+
+func copyTwab(twab schema.Twab) schema.Twab {
+	observations := make([]schema.TwabObservation, len(twab.Observations))
+	for i, observation := range twab.Observations {
+		observations[i] = schema.TwabObservation{
+			Timestamp:  observation.Timestamp,
+			Cumulative: copyAmount(observation.Cumulative),
+			Balance:    copyAmount(observation.Balance),
+		}
+	}
+	twab.Observations = observations
+	return twab
+}
+
+// Twab gets the time-weighted balance history of an account
+func (b *BasicToken) Twab(accId string) (schema.Twab, bool) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	twab, exists := b.twabs[accId]
+	if !exists {
+		return schema.Twab{}, false
+	}
+	return copyTwab(twab), true
+}
+
+// SetTwab replaces the time-weighted balance history of an account
+func (b *BasicToken) SetTwab(accId string, twab schema.Twab) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	if b.twabs == nil {
+		b.twabs = make(map[string]schema.Twab)
+	}
+	b.twabs[accId] = copyTwab(twab)
+}
+
+// TotalSupplyTwab gets the time-weighted history of the total supply
+func (b *BasicToken) TotalSupplyTwab() (schema.Twab, bool) {
+	b.rwlock.RLock()
+	defer b.rwlock.RUnlock()
+	if b.totalSupplyTwab == nil {
+		return schema.Twab{}, false
+	}
+	return copyTwab(*b.totalSupplyTwab), true
+}
+
+// SetTotalSupplyTwab replaces the time-weighted history of the total supply
+func (b *BasicToken) SetTotalSupplyTwab(twab schema.Twab) {
+	b.rwlock.Lock()
+	defer b.rwlock.Unlock()
+	twab = copyTwab(twab)
+	b.totalSupplyTwab = &twab
+}
+
+func scaleTwab(twab schema.Twab, numerator, denominator *big.Int) schema.Twab {
+	for i, observation := range twab.Observations {
+		twab.Observations[i].Cumulative = scaleAmount(observation.Cumulative, numerator, denominator)
+		twab.Observations[i].Balance = scaleAmount(observation.Balance, numerator, denominator)
+	}
+	return twab
+}
+
+// scaleTwabs expresses the time-weighted histories in the new unit, the caller must hold the lock
+func (b *BasicToken) scaleTwabs(numerator, denominator *big.Int) {
+	for accId, twab := range b.twabs {
+		b.twabs[accId] = scaleTwab(twab, numerator, denominator)
+	}
+	if b.totalSupplyTwab != nil {
+		twab := scaleTwab(*b.totalSupplyTwab, numerator, denominator)
+		b.totalSupplyTwab = &twab
+	}
+}
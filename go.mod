@@ -3,6 +3,7 @@ module github.com/aox-labs/hymx-vmtoken
 go 1.24.3
 
 require (
+	github.com/btcsuite/btcd/btcutil v1.1.6
 	github.com/ethereum/go-ethereum v1.14.11
 	github.com/everFinance/goether v1.2.0
 	github.com/gin-gonic/gin v1.10.0
@@ -20,7 +21,6 @@ require (
 require (
 	github.com/bits-and-blooms/bitset v1.14.3 // indirect
 	github.com/btcsuite/btcd/btcec/v2 v2.3.4 // indirect
-	github.com/btcsuite/btcd/btcutil v1.1.6 // indirect
 	github.com/bytedance/sonic v1.11.6 // indirect
 	github.com/bytedance/sonic/loader v0.1.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
@@ -0,0 +1,44 @@
+package rebase
+
+import (
+	"encoding/json"
+	"maps"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+)
+
+func (t *Token) initCache() (cache map[string]string) {
+	if t.db.CacheInitial() {
+		return
+	}
+	defer t.db.CacheInitialed()
+	cache = map[string]string{}
+	maps.Copy(cache, t.basic.CacheBalances())
+	maps.Copy(cache, t.basic.CacheTotalSupply())
+	maps.Copy(cache, t.basic.CacheFrozen())
+	maps.Copy(cache, t.basic.CachePolicy())
+	maps.Copy(cache, t.basic.CacheEmission())
+	maps.Copy(cache, t.cacheTokenInfo())
+	return
+}
+
+func (t *Token) cacheTokenInfo() map[string]string {
+	info := t.db.Info()
+	cacheInfo := schema.RebaseCacheInfo{
+		Name:            info.Name,
+		Ticker:          info.Ticker,
+		Decimals:        info.Decimals,
+		Logo:            info.Logo,
+		Description:     info.Description,
+		Owner:           t.db.Owner(),
+		MintOwner:       t.db.MintOwner(),
+		MaxSupply:       t.db.MaxSupply().String(),
+		ComplianceOwner: t.db.ComplianceOwner(),
+		Rebaser:         t.db.Rebaser(),
+		Index:           t.db.Index().String(),
+	}
+	res, _ := json.Marshal(cacheInfo)
+	return map[string]string{
+		"info": string(res),
+	}
+}
@@ -0,0 +1,136 @@
+package rebase
+
+import (
+	"maps"
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+	goarSchema "github.com/permadao/goar/schema"
+)
+
+func (t *Token) handleInfo(from string) (res vmmSchema.Result) {
+	info := t.db.Info()
+	tags := []goarSchema.Tag{
+		{Name: "Name", Value: info.Name},
+		{Name: "Ticker", Value: info.Ticker},
+		{Name: "Logo", Value: info.Logo},
+		{Name: "Decimals", Value: info.Decimals},
+		{Name: "Description", Value: info.Description},
+		{Name: "Owner", Value: t.db.Owner()},
+		{Name: "MintOwner", Value: t.db.MintOwner()},
+		{Name: "MaxSupply", Value: t.db.MaxSupply().String()},
+		{Name: "ComplianceOwner", Value: t.db.ComplianceOwner()},
+		{Name: "Rebaser", Value: t.db.Rebaser()},
+		{Name: "Index", Value: t.db.Index().String()},
+	}
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags:   tags,
+		},
+	}
+	res.Cache = t.initCache()
+	return
+}
+
+func (t *Token) handleSetParams(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	// Check ownership
+	if from != t.db.Owner() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	rebaser := ""
+	if meta.Params["Rebaser"] != "" {
+		var err error
+		_, rebaser, err = utils.IDCheck(meta.Params["Rebaser"])
+		if err != nil {
+			res.Error = schema.ErrInvalidRebaser
+			return
+		}
+	}
+
+	// Handle base token parameters
+	res = t.basic.Apply(from, meta)
+	if res.Error != nil {
+		return
+	}
+
+	if rebaser != "" {
+		t.db.SetRebaser(rebaser)
+	}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	return
+}
+
+// handleRebase changes the index to expand or contract the supply (Rebaser only)
+func (t *Token) handleRebase(from string, params map[string]string) (res vmmSchema.Result) {
+	if from != t.db.Rebaser() {
+		res.Error = schema.ErrIncorrectOwner
+		return
+	}
+
+	indexStr := params["Index"]
+	if indexStr == "" {
+		res.Error = schema.ErrMissingIndex
+		return
+	}
+	index, ok := new(big.Int).SetString(indexStr, 10)
+	if !ok || index.Sign() <= 0 {
+		res.Error = schema.ErrInvalidIndex
+		return
+	}
+
+	// Rewards accrue on the balances before the rebase
+	if err := t.basic.SettleAllRewards(); err != nil {
+		res.Error = err
+		return
+	}
+
+	oldBalances, err := t.db.Balances()
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	// The rebased total supply must respect the max supply
+	oldIndex := t.db.Index()
+	oldTotalSupply := t.db.GetTotalSupply()
+	t.db.SetIndex(index)
+	if maxSupply := t.db.MaxSupply(); maxSupply.Sign() > 0 && t.db.GetTotalSupply().Cmp(maxSupply) > 0 {
+		t.db.SetIndex(oldIndex)
+		res.Error = schema.ErrInsufficientMaxSupply
+		return
+	}
+	balances, err := t.db.Balances()
+	if err != nil {
+		t.db.SetIndex(oldIndex)
+		res.Error = err
+		return
+	}
+	t.basic.ObserveAllTwabs(oldBalances, balances, oldTotalSupply)
+
+	res.Messages = []*vmmSchema.ResMessage{
+		{
+			Target: from,
+			Tags: []goarSchema.Tag{
+				{Name: "Action", Value: "Rebase-Notice"},
+				{Name: "OldIndex", Value: oldIndex.String()},
+				{Name: "Index", Value: index.String()},
+				{Name: "OldTotalSupply", Value: oldTotalSupply.String()},
+				{Name: "TotalSupply", Value: t.db.GetTotalSupply().String()},
+				{Name: "Ticker", Value: t.db.Info().Ticker},
+			},
+		},
+	}
+
+	// Every balance changes with the index
+	res.Cache = map[string]string{}
+	maps.Copy(res.Cache, t.cacheTokenInfo())
+	maps.Copy(res.Cache, t.basic.CacheBalances())
+	maps.Copy(res.Cache, t.basic.CacheTotalSupply())
+	return
+}
@@ -0,0 +1,90 @@
+package rebase
+
+import (
+	"maps"
+	"math/big"
+
+	"github.com/aox-labs/hymx-vmtoken/basic"
+	"github.com/aox-labs/hymx-vmtoken/db/cache"
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
+	"github.com/hymatrix/hymx/vmm/utils"
+)
+
+type Token struct {
+	basic *basic.Token
+	db    schema.RebaseDB
+}
+
+func Spawn(env vmmSchema.Env) (vm vmmSchema.Vm, err error) {
+	// Reuse the basic token params validation
+	basicVm, err := basic.Spawn(env)
+	if err != nil {
+		return
+	}
+	basicDB := basicVm.(*basic.Token).DB.(*cache.BasicToken)
+
+	// Parse and validate Rebaser with default value
+	rebaserStr := env.Meta.Params["Rebaser"]
+	if rebaserStr == "" {
+		rebaserStr = env.Meta.AccId // Default to owner
+	}
+	_, rebaser, err := utils.IDCheck(rebaserStr)
+	if err != nil {
+		err = schema.ErrInvalidRebaser
+		return
+	}
+
+	// Parse and validate Index with default value
+	index := new(big.Int).Set(schema.RebaseIndexPrecision)
+	if indexStr := env.Meta.Params["Index"]; indexStr != "" {
+		var ok bool
+		index, ok = new(big.Int).SetString(indexStr, 10)
+		if !ok || index.Sign() <= 0 {
+			err = schema.ErrInvalidIndex
+			return
+		}
+	}
+
+	db := cache.NewRebaseToken(basicDB, index, rebaser)
+	return &Token{
+		basic: &basic.Token{DB: db},
+		db:    db,
+	}, nil
+}
+
+func (t *Token) Apply(from string, meta vmmSchema.Meta) (res vmmSchema.Result) {
+	t.basic.Now = meta.Timestamp
+	switch meta.Action {
+	case "Info":
+		res = t.handleInfo(from)
+	case "Set-Params":
+		// The basic token mints the accrued emission
+		return t.handleSetParams(from, meta)
+	case "Rebase":
+		res = t.handleRebase(from, meta.Params)
+	default:
+		// Every other action is served by the basic token on top of the shares DB, emission included
+		res = t.basic.Apply(from, meta)
+		if _, ok := res.Cache["info"]; ok {
+			maps.Copy(res.Cache, t.cacheTokenInfo())
+		}
+		return
+	}
+
+	// Any successful message mints the accrued emission
+	t.basic.AutoEmit(&res)
+	return
+}
+
+func (t *Token) Checkpoint() (string, error) {
+	return t.db.Checkpoint()
+}
+
+func (t *Token) Restore(data string) error {
+	return t.db.Restore(data)
+}
+
+func (t *Token) Close() error {
+	return nil
+}
@@ -32,4 +32,113 @@ var (
 	ErrLockAmountEmpty        = errors.New("err_lock_amount_empty")
 	ErrInsufficientLockAmount = errors.New("err_insufficient_lock_amount")
 	ErrMissingBurnFee         = errors.New("err_missing_burn_fee")
+
+	ErrMissingAccount            = errors.New("err_missing_account")
+	ErrInvalidAccount            = errors.New("err_invalid_account")
+	ErrMissingMerchant           = errors.New("err_missing_merchant")
+	ErrInvalidMerchant           = errors.New("err_invalid_merchant")
+	ErrIncorrectMerchant         = errors.New("err_incorrect_merchant")
+	ErrMissingHoldId             = errors.New("err_missing_hold_id")
+	ErrHoldNotFound              = errors.New("err_hold_not_found")
+	ErrHoldExpired               = errors.New("err_hold_expired")
+	ErrHoldNotExpired            = errors.New("err_hold_not_expired")
+	ErrInvalidExpiresAt          = errors.New("err_invalid_expires_at")
+	ErrInsufficientHoldAllowance = errors.New("err_insufficient_hold_allowance")
+
+	ErrInvalidComplianceOwner = errors.New("err_invalid_compliance_owner")
+	ErrAccountFrozen          = errors.New("err_account_frozen")
+	ErrMissingReason          = errors.New("err_missing_reason")
+
+	ErrInvalidPolicy               = errors.New("err_invalid_policy")
+	ErrPolicyMaxTransferAmount     = errors.New("err_policy_max_transfer_amount")
+	ErrPolicyMaxBalance            = errors.New("err_policy_max_balance")
+	ErrPolicyRecipientNotAllowed   = errors.New("err_policy_recipient_not_allowed")
+	ErrPolicyOutsideTransferWindow = errors.New("err_policy_outside_transfer_window")
+
+	ErrInvalidRebaser = errors.New("err_invalid_rebaser")
+	ErrMissingIndex   = errors.New("err_missing_index")
+	ErrInvalidIndex   = errors.New("err_invalid_index")
+
+	ErrInvalidRatio            = errors.New("err_invalid_ratio")
+	ErrInvalidDecimals         = errors.New("err_invalid_decimals")
+	ErrDecimalsLocked          = errors.New("err_decimals_locked")
+	ErrInvalidMultisig         = errors.New("err_invalid_multisig")
+	ErrIncorrectSigner         = errors.New("err_incorrect_signer")
+	ErrMissingProposalId       = errors.New("err_missing_proposal_id")
+	ErrProposalNotFound        = errors.New("err_proposal_not_found")
+	ErrProposalAlreadyApproved = errors.New("err_proposal_already_approved")
+
+	ErrNoRewards               = errors.New("err_no_rewards")
+	ErrNoEligibleSupply        = errors.New("err_no_eligible_supply")
+	ErrInvalidRewardToken      = errors.New("err_invalid_reward_token")
+	ErrInvalidRewardExclusions = errors.New("err_invalid_reward_exclusions")
+	ErrInvalidRewardTokens     = errors.New("err_invalid_reward_tokens")
+	ErrRewardTokenNotAllowed   = errors.New("err_reward_token_not_allowed")
+
+	ErrInvalidTwabRange = errors.New("err_invalid_twab_range")
+	ErrTwabOutOfRange   = errors.New("err_twab_out_of_range")
+
+	ErrInvalidEmission = errors.New("err_invalid_emission")
+	ErrMissingEmission = errors.New("err_missing_emission")
+
+	ErrIdempotencyKeyReused   = errors.New("err_idempotency_key_reused")
+	ErrTooManyIdempotencyKeys = errors.New("err_too_many_idempotency_keys")
+
+	ErrInvalidMintRetention = errors.New("err_invalid_mint_retention")
+	ErrInvalidBurnRetention = errors.New("err_invalid_burn_retention")
+	ErrMissingBlockHeight   = errors.New("err_missing_block_height")
+	ErrInvalidBlockHeight   = errors.New("err_invalid_block_height")
+	ErrMintBelowRetention   = errors.New("err_mint_below_retention")
+
+	ErrMintRecordNotFound = errors.New("err_mint_record_not_found")
+	ErrBurnRecordNotFound = errors.New("err_burn_record_not_found")
+	ErrInvalidPagination  = errors.New("err_invalid_pagination")
+
+	ErrBurnNotPending      = errors.New("err_burn_not_pending")
+	ErrMissingTargetTxHash = errors.New("err_missing_target_tx_hash")
+	ErrInvalidTargetTxHash = errors.New("err_invalid_target_tx_hash")
+
+	ErrInvalidBridgeAdmin      = errors.New("err_invalid_bridge_admin")
+	ErrInvalidSourceToken      = errors.New("err_invalid_source_token")
+	ErrUnregisteredSourceToken = errors.New("err_unregistered_source_token")
+	ErrSourceTokenRegistered   = errors.New("err_source_token_registered")
+	ErrSourceTokenDisabled     = errors.New("err_source_token_disabled")
+	ErrSourceTokenLocked       = errors.New("err_source_token_locked")
+	ErrInvalidDustPolicy       = errors.New("err_invalid_dust_policy")
+	ErrDustAmount              = errors.New("err_dust_amount")
+
+	ErrInvalidRelayers       = errors.New("err_invalid_relayers")
+	ErrIncorrectRelayer      = errors.New("err_incorrect_relayer")
+	ErrAttestationRequired   = errors.New("err_attestation_required")
+	ErrMissingMintTxHash     = errors.New("err_missing_mint_tx_hash")
+	ErrInvalidMintTxHash     = errors.New("err_invalid_mint_tx_hash")
+	ErrAlreadyAttested       = errors.New("err_already_attested")
+	ErrMintAttestationFrozen = errors.New("err_mint_attestation_frozen")
+	ErrAttestationNotFound   = errors.New("err_attestation_not_found")
+
+	ErrInvalidFeeModel = errors.New("err_invalid_fee_model")
+
+	ErrInvalidFeeShares = errors.New("err_invalid_fee_shares")
+	ErrInsufficientFees = errors.New("err_insufficient_fees")
+
+	ErrInvalidBurnRoute = errors.New("err_invalid_burn_route")
+	ErrNoBurnRoute      = errors.New("err_no_burn_route")
+
+	ErrMissingReference = errors.New("err_missing_reference")
+
+	ErrInvalidReserveAttestors  = errors.New("err_invalid_reserve_attestors")
+	ErrInvalidReserveTolerance  = errors.New("err_invalid_reserve_tolerance")
+	ErrIncorrectReserveAttestor = errors.New("err_incorrect_reserve_attestor")
+	ErrInvalidReserveReport     = errors.New("err_invalid_reserve_report")
+	ErrStaleReserveReport       = errors.New("err_stale_reserve_report")
+	ErrReserveShortfall         = errors.New("err_reserve_shortfall")
+
+	ErrChainHalted   = errors.New("err_chain_halted")
+	ErrAlertNotFound = errors.New("err_alert_not_found")
+
+	ErrInvalidBridgeLimit = errors.New("err_invalid_bridge_limit")
+	ErrMintPaused         = errors.New("err_mint_paused")
+	ErrBurnPaused         = errors.New("err_burn_paused")
+	ErrExceedsMaxPerTx    = errors.New("err_exceeds_max_per_tx")
+	ErrDailyCapExceeded   = errors.New("err_daily_cap_exceeded")
 )
@@ -11,6 +11,8 @@ type BasicDB interface {
 	SetMintOwner(newOwner string)
 	MaxSupply() *big.Int
 	SetMaxSupply(*big.Int) error
+	ComplianceOwner() string
+	SetComplianceOwner(newOwner string)
 
 	GetTotalSupply() *big.Int
 	SetTotalSupply(*big.Int)
@@ -18,6 +20,62 @@ type BasicDB interface {
 	Balances() (map[string]*big.Int, error)
 	UpdateBalance(accId string, amount *big.Int) error
 
+	GetHold(holdId string) (Hold, bool)
+	SetHold(hold Hold)
+	DeleteHold(holdId string)
+	HoldsOf(accId string) []Hold
+	PruneExpiredHolds(now int64) int
+	HoldAllowance(accId, merchant string) *big.Int
+	SetHoldAllowance(accId, merchant string, amount *big.Int)
+
+	IsFrozen(accId string) bool
+	SetFrozen(accId string, frozen bool)
+	FrozenAccounts() []string
+	AppendAuditRecord(record AuditRecord)
+	AuditRecords() []AuditRecord
+
+	Policy() Policy
+	SetPolicy(policy Policy)
+
+	// Redenominate atomically multiplies every balance, the total supply, the max supply,
+	// holds, hold allowances and policy amounts by numerator/denominator rounding down.
+	// It returns the dust lost by rounding the balances down and the new balances.
+	Redenominate(numerator, denominator *big.Int) (dust *big.Int, balances map[string]*big.Int)
+	RedenominateMultisig() (signers []string, threshold int)
+	SetRedenominateMultisig(signers []string, threshold int)
+	PendingRedenomination() (Redenomination, bool)
+	SetPendingRedenomination(proposal *Redenomination)
+
+	// Rewards are tracked with a cumulative reward per token (scaled by RewardPrecision) for every reward token
+	RewardTokens() []string
+	RewardPerToken(rewardToken string) *big.Int
+	SetRewardPerToken(rewardToken string, rewardPerToken *big.Int)
+	RewardAccount(accId, rewardToken string) RewardAccount
+	SetRewardAccount(accId, rewardToken string, account RewardAccount)
+	RewardExclusions() []string
+	SetRewardExclusions(accIds []string)
+	AllowedRewardTokens() []string
+	SetAllowedRewardTokens(rewardTokens []string)
+
+	Twab(accId string) (Twab, bool)
+	SetTwab(accId string, twab Twab)
+	TotalSupplyTwab() (Twab, bool)
+	SetTotalSupplyTwab(twab Twab)
+
+	Emission() (Emission, bool)
+	SetEmission(emission *Emission) // nil removes the schedule and resets the progress
+	// EmissionProgress returns the scheduled amount already processed and the last emission time (UnixMilli)
+	EmissionProgress() (emitted *big.Int, lastEmission int64)
+	SetEmissionProgress(emitted *big.Int, lastEmission int64)
+
+	IdempotencyRecord(sender, key string) (IdempotencyRecord, bool)
+	AddIdempotencyRecord(record IdempotencyRecord)
+	IdempotencyKeyCount(sender string) int
+	// IdempotencyKeyTotal returns the number of records of every sender
+	IdempotencyKeyTotal() int
+	// PruneIdempotencyRecords drops the records older than expiredBefore (UnixMilli)
+	PruneIdempotencyRecords(expiredBefore int64)
+
 	CacheInitial() bool
 	CacheInitialed()
 
@@ -25,25 +83,132 @@ type BasicDB interface {
 	Restore(data string) error
 }
 
+// RebaseDB stores shares and exposes every amount of BasicDB as
+// shares * index / (RebaseIndexPrecision * RebaseSharePrecision)
+type RebaseDB interface {
+	BasicDB
+
+	Index() *big.Int
+	SetIndex(index *big.Int)
+	Rebaser() string
+	SetRebaser(addr string)
+}
+
 type CrossChainDB interface {
-	GetMintedRecord(mintTxHash string) (string, bool)
-	SetMintedRecord(mintTxHash, chainType string)
+	GetMintedRecord(mintTxHash string) (MintRecord, bool)
+	AddMintedRecord(record MintRecord)
+	MintRetention() MintRetention
+	SetMintRetention(retention MintRetention)
+	// MintWatermark is the source chain block height at or below which mints are rejected,
+	// because their records may have been pruned
+	MintWatermark(chainType string) uint64
+	// ResetMintWatermark lowers the highest seen block height and the watermark of a chain to a block height
+	ResetMintWatermark(chainType string, blockHeight uint64)
+	// MintArchived reports whether a mint tx hash was minted and its record pruned by age
+	MintArchived(mintTxHash string) bool
+	// CanonicalizeMintTxHashes re-keys the minted records, the archive and the attestations by the canonical tx hash
+	CanonicalizeMintTxHashes(canonical func(chainType, mintTxHash string) string)
+	// PruneMintedRecords drops the records outside the retention, the records below the block retention
+	// raise the watermarks and the tx hashes of the records older than the age retention are archived
+	PruneMintedRecords(now int64)
+	// MintRecords returns the records of a chain, or of all chains when empty, in insertion order
+	MintRecords(chainType string) []MintRecord
+
+	GetBurnRecord(burnTxHash string) (BurnRecord, bool)
+	// AddBurnRecord stores a new record or replaces an existing one in place
+	AddBurnRecord(record BurnRecord)
+	// BurnRecords returns the records of a chain, or of all chains when empty, in insertion order
+	BurnRecords(chainType string) []BurnRecord
+	BurnRecordMaxAge() int64
+	SetBurnRecordMaxAge(maxAge int64)
+	// PruneBurnRecords drops the resolved records older than the burn record MaxAge,
+	// pending burns are kept
+	PruneBurnRecords(now int64)
 
+	// GetSourceTokenChains returns the chain type of every registered source token
 	GetSourceTokenChains() map[string]string
 	GetSourceTokenChain(tokenId string) (string, bool)
-	SetSourceTokenChain(tokenId, chainType string)
+	GetSourceTokens() map[string]SourceToken
+	GetSourceToken(tokenId string) (SourceToken, bool)
+	SetSourceToken(sourceToken SourceToken)
+
+	GetBridgeAdmin() string
+	SetBridgeAdmin(addr string)
+
+	GetDustPolicy() string
+	SetDustPolicy(dustPolicy string)
+
+	// RelayerSet returns the relayers attesting mints and the attestation threshold
+	RelayerSet() (relayers []string, threshold int)
+	// SetRelayerSet sets the relayers, a zero threshold disables the attested minting
+	SetRelayerSet(relayers []string, threshold int)
+	MintAttestation(mintTxHash string) (MintAttestation, bool)
+	SetMintAttestation(attestation MintAttestation)
+	DeleteMintAttestation(mintTxHash string)
 
 	GetSourceLockAmounts() map[string]*big.Int
 	GetSourceLockAmount(tokenId, chainType string) (*big.Int, bool)
 	SetSourceLockAmount(tokenId, chainType string, amount *big.Int)
+	// AppendLockAuditRecord appends a record to the lock amount audit log
+	AppendLockAuditRecord(record LockAuditRecord)
+	LockAuditRecords() []LockAuditRecord
 
 	GetBurnFees() map[string]*big.Int
 	GetBurnFee(chainType string) (*big.Int, bool)
 	SetBurnFee(chainType string, amount *big.Int)
+	// GetBurnFeeModels returns the fee models keyed by chainType or chainType:targetTokenId
+	GetBurnFeeModels() map[string]FeeModel
+	GetBurnFeeModel(key string) (FeeModel, bool)
+	// SetBurnFeeModel sets the fee model of a key, nil removes it
+	SetBurnFeeModel(key string, model *FeeModel)
+	// GetMintFeeModels returns the fee models keyed by chainType or chainType:sourceTokenId
+	GetMintFeeModels() map[string]FeeModel
+	GetMintFeeModel(key string) (FeeModel, bool)
+	SetMintFeeModel(key string, model *FeeModel)
+
+	// ReserveAttestors returns the accounts that can send reserve reports
+	ReserveAttestors() []string
+	SetReserveAttestors(attestors []string)
+	// ReserveTolerance returns the shortfall of a reserve under its lock amount that pauses the mints, in basis points
+	ReserveTolerance() int64
+	SetReserveTolerance(tolerance int64)
+	ReserveReports() map[string]ReserveReport
+	ReserveReport(tokenId string) (ReserveReport, bool)
+	SetReserveReport(report ReserveReport)
+
+	// BridgeLimits returns the bridge limits keyed by chainType or chainType:tokenId
+	BridgeLimits() map[string]BridgeLimit
+	BridgeLimit(key string) (BridgeLimit, bool)
+	// SetBridgeLimit sets the bridge limit of a key, nil removes it
+	SetBridgeLimit(key string, limit *BridgeLimit)
+	// BridgeFlows returns the recent mints and burns keyed as the bridge limits
+	BridgeFlows() map[string]BridgeFlow
+	BridgeFlow(key string) BridgeFlow
+	SetBridgeFlow(key string, flow BridgeFlow)
+
+	// HaltedChains returns the invariant alerts halting chain types, keyed by chain type
+	HaltedChains() map[string]InvariantAlert
+	HaltedChain(chainType string) (InvariantAlert, bool)
+	SetHaltedChain(alert InvariantAlert)
+	DeleteHaltedChain(chainType string)
+
+	// BurnRoutes returns the burn routes keyed by chain type
+	BurnRoutes() map[string]BurnRoute
+	BurnRoute(chainType string) (BurnRoute, bool)
+	// SetBurnRoute sets the burn route of a chain type, nil removes it
+	SetBurnRoute(chainType string, route *BurnRoute)
 
 	GetFeeRecipient() string
 	SetFeeRecipient(addr string)
 
+	// FeeLedgers returns the fee ledger of every chain type
+	FeeLedgers() map[string]FeeLedger
+	FeeLedger(chainType string) FeeLedger
+	SetFeeLedger(chainType string, ledger FeeLedger)
+	// FeeShares returns the recipients of the fee withdrawals, empty pays the fee recipient
+	FeeShares() []FeeShare
+	SetFeeShares(shares []FeeShare)
+
 	GetBurnProcessor() string
 	SetBurnProcessor(addr string)
 
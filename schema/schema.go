@@ -1,10 +1,27 @@
 package schema
 
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
 const (
 	VmTokenBasicModuleFormat           = "hymx.basic.token.0.0.1"
 	VmTokenCrossChainMultiModuleFormat = "hymx.cross.chain.multi.token.0.0.1"
+	VmTokenRebaseModuleFormat          = "hymx.rebase.token.0.0.1"
 )
 
+// RebaseIndexPrecision is the index of a rebasing token that maps RebaseSharePrecision shares to one unit
+var RebaseIndexPrecision = big.NewInt(1e18)
+
+// RebaseSharePrecision is the number of shares per unit at the RebaseIndexPrecision index, it keeps
+// the rounding of the share conversions far below one unit
+var RebaseSharePrecision = big.NewInt(1e18)
+
+// RewardPrecision scales the cumulative reward per token of a distribution
+var RewardPrecision = big.NewInt(1e18)
+
 type Info struct {
 	Id          string
 	Name        string
@@ -23,6 +40,28 @@ type BasicCacheInfo struct {
 	Owner       string
 	MintOwner   string
 	MaxSupply   string
+
+	ComplianceOwner string
+}
+
+type RebaseCacheInfo struct {
+	Name            string
+	Ticker          string
+	Decimals        string
+	Logo            string
+	Description     string
+	Owner           string
+	MintOwner       string
+	MaxSupply       string
+	ComplianceOwner string
+	Rebaser         string
+	Index           string
+}
+
+type EmissionCacheInfo struct {
+	Emission
+	Emitted      string
+	LastEmission int64
 }
 
 type CrossChainCacheInfo struct {
@@ -33,9 +72,325 @@ type CrossChainCacheInfo struct {
 	Description       string
 	Owner             string
 	MintOwner         string
+	ComplianceOwner   string
 	BurnFees          string
 	FeeRecipient      string
 	BurnProcessor     string
 	SourceTokenChains string
 	SourceLockAmounts string
+	MintRetention     string
+	BurnRecordMaxAge  string
+	BridgeAdmin       string
+	SourceTokens      string
+	DustPolicy        string
+	Relayers          string
+	RelayerThreshold  int
+	BurnFeeModels     string
+	MintFeeModels     string
+	FeeShares         string
+	BurnRoutes        string
+	ReserveAttestors  string
+	ReserveTolerance  int64
+	Reserves          string
+	HaltedChains      string
+	BridgeLimits      string
+	BridgeUsage       string
+}
+
+// FeeModel computes the fee of an amount as Flat + amount * Bps / 10000, bounded by Min and Max.
+// The highest tier reached by the amount replaces Flat and Bps. Amounts are decimal strings.
+type FeeModel struct {
+	Flat  string    `json:",omitempty"`
+	Bps   int64     `json:",omitempty"`
+	Min   string    `json:",omitempty"`
+	Max   string    `json:",omitempty"` // empty for no maximum
+	Tiers []FeeTier `json:",omitempty"` // in ascending From order
+}
+
+// FeeTier is the fee of the amounts from From up to the next tier
+type FeeTier struct {
+	From string
+	Flat string `json:",omitempty"`
+	Bps  int64  `json:",omitempty"`
+}
+
+// BridgeLimit restricts the mints and burns of a chain type or of a source token, amounts are in
+// wrapped units and empty amounts are unlimited
+type BridgeLimit struct {
+	MintPaused      bool   `json:",omitempty"`
+	BurnPaused      bool   `json:",omitempty"`
+	MaxPerTx        string `json:",omitempty"` // max amount of a single mint or burn
+	DailyInflowCap  string `json:",omitempty"` // max amount minted over a rolling day
+	DailyOutflowCap string `json:",omitempty"` // max amount burned over a rolling day
+}
+
+// BridgeFlow is the amounts minted and burned under a bridge limit key over the rolling day, in hourly buckets
+type BridgeFlow struct {
+	Inflows  []FlowEntry
+	Outflows []FlowEntry
+}
+
+// FlowEntry is the amount minted or burned in the hour starting at Timestamp
+type FlowEntry struct {
+	Amount    string
+	Timestamp int64 // UnixMilli
+}
+
+// BurnRoute picks the source token of the burns to a chain type without TargetTokenId
+type BurnRoute struct {
+	Strategy  string
+	Preferred []string `json:",omitempty"` // source token ids tried first by the preferred strategy
+}
+
+const (
+	// BurnRouteLargest burns to the source token with the largest lock amount
+	BurnRouteLargest = "largest"
+	// BurnRoutePreferred burns to the first preferred source token with enough lock amount
+	BurnRoutePreferred = "preferred"
+	// BurnRouteSplit splits the burn across the source tokens by descending lock amount
+	BurnRouteSplit = "split"
+)
+
+// LockAuditRecord is an entry of the append-only audit log of the lock amount corrections
+type LockAuditRecord struct {
+	Action          string // Adjust-Lock-Amount or Move-Lock-Amount
+	Operator        string
+	SourceTokenId   string
+	ChainType       string
+	PreviousAmount  string // lock amount before the correction, in source units
+	Amount          string // lock amount after the correction, in source units
+	ToSourceTokenId string `json:",omitempty"` // destination of a move
+	ToChainType     string `json:",omitempty"`
+	ToPrevious      string `json:",omitempty"`
+	ToAmount        string `json:",omitempty"`
+	Reason          string
+	Reference       string // incident or migration reference
+	ItemId          string
+	Timestamp       int64 // UnixMilli
+}
+
+// ReserveReport is the latest reserve observed by an attestor for a source token
+type ReserveReport struct {
+	SourceTokenId string
+	ChainType     string
+	Reserve       string // observed reserve in source units
+	BlockHeight   uint64 // source chain block height of the observation
+	Timestamp     int64  // source chain timestamp of the observation
+	Attestor      string
+	ReportedAt    int64 // UnixMilli
+	MintPaused    bool  // the reserve fell below the lock amount beyond the tolerance
+}
+
+// Invariants checked after every cross-chain mint and burn
+const (
+	InvariantSupplyBacking = "supply-backing" // the wrapped lock amounts cover the supply and the available fees
+	InvariantNegativeLock  = "negative-lock"  // no lock amount is negative
+)
+
+// InvariantAlert is a violated invariant that halts the mints and burns of a chain type until acknowledged
+type InvariantAlert struct {
+	ChainType     string
+	Invariant     string
+	Trigger       string // Mint, Burn, Adjust-Lock-Amount or Move-Lock-Amount
+	ItemId        string
+	SourceTokenId string // empty for the supply backing
+	Actual        string
+	Expected      string
+	TotalSupply   string
+	Fees          string // available fees of every chain type
+	Timestamp     int64
+}
+
+// FeeLedger is the bridge fees of a chain type, the fees accrued and not withdrawn are out of the supply
+type FeeLedger struct {
+	Accrued   *big.Int // fees and dust collected, net of the refunded fees
+	Withdrawn *big.Int
+}
+
+// FeeShare is the share of the fee withdrawals paid to a recipient
+type FeeShare struct {
+	Recipient string
+	Share     int64
+}
+
+// MintAttestation collects the relayer attestations of a cross-chain mint until the threshold is reached
+type MintAttestation struct {
+	MintTxHash      string
+	Recipient       string
+	Quantity        string
+	SourceChainType string
+	SourceTokenId   string
+	BlockHeight     string
+	Attesters       []string
+	Conflicts       []string // relayers whose attestation didn't match
+	Frozen          bool     // a conflicting attestation freezes the mint until the owner clears it
+}
+
+// SourceToken is a registered source chain token of a cross-chain token
+type SourceToken struct {
+	TokenId   string
+	ChainType string
+	Name      string
+	Decimals  string
+	Disabled  bool // a disabled source token can't be minted, burns still release the locked amount
+}
+
+// MintRecord is the record of a cross-chain mint, it also protects against replays
+type MintRecord struct {
+	MintTxHash      string
+	SourceChainType string
+	SourceTokenId   string
+	Recipient       string
+	Quantity        string // minted amount in wrapped units
+	SourceQuantity  string // locked amount in source units
+	Dust            string // source units that can't be represented in wrapped units
+	Fee             string
+	BlockHeight     uint64 // source chain block height of the mint tx, 0 when unknown
+	Timestamp       int64  // UnixMilli, 0 for records restored from the legacy store
+	MessageId       string // Hymx message id of the mint
+}
+
+// BurnRecord is the record of a cross-chain burn
+type BurnRecord struct {
+	BurnTxHash      string // Hymx message id of the burn
+	Sender          string
+	Recipient       string // recipient on the target chain
+	Quantity        string // net amount burnt in wrapped units
+	SourceQuantity  string // amount released on the target chain in source units
+	Dust            string // wrapped units that can't be represented in source units, accrued with the fee
+	Fee             string
+	FeeRecipient    string // fee recipient credited by the burns before the fee ledger, empty when accrued in the ledger
+	TargetChainType string
+	TargetTokenId   string
+	Timestamp       int64 // UnixMilli
+	Status          string
+	TargetTxHash    string // target chain tx hash of a completed burn
+	RefundReason    string
+	FeeRefunded     bool
+	ResolvedAt      int64 // UnixMilli, when the burn was completed or refunded
+}
+
+const (
+	// DustPolicyReject rejects mints and burns whose amount can't be converted exactly
+	DustPolicyReject = "reject"
+	// DustPolicyFee keeps the dust: mint dust stays locked on the source chain, burn dust accrues with the fee
+	DustPolicyFee = "fee"
+)
+
+const (
+	BurnStatusPending   = "pending"
+	BurnStatusCompleted = "completed"
+	BurnStatusRefunded  = "refunded"
+)
+
+// MintRetention bounds the cross-chain mint records, a zero field disables the rule
+type MintRetention struct {
+	MaxAge    int64  // UnixMilli
+	MaxBlocks uint64 // source chain blocks behind the highest seen block height
+}
+
+type Hold struct {
+	Id        string
+	Account   string
+	Merchant  string
+	Quantity  *big.Int
+	ExpiresAt int64 // UnixMilli, 0 means the hold never expires
+}
+
+// AuditRecord is an entry of the append-only admin audit log
+type AuditRecord struct {
+	Action    string
+	Operator  string
+	Account   string
+	Recipient string
+	Quantity  string
+	Reason    string
+	ItemId    string
+	Timestamp int64  // UnixMilli
+	Ratio     string `json:",omitempty"` // numerator/denominator of a redenomination
+}
+
+// Policy is the declarative transfer policy of a token, amounts are decimal strings
+type Policy struct {
+	MaxTransferAmount string           `json:",omitempty"` // max amount per transfer
+	MaxBalance        string           `json:",omitempty"` // max balance per holder
+	AllowlistOnly     bool             `json:",omitempty"` // only allowlisted recipients can receive
+	Allowlist         []string         `json:",omitempty"`
+	TransferWindows   []TransferWindow `json:",omitempty"` // transfers are only allowed inside one of the windows
+}
+
+type TransferWindow struct {
+	Start int64 // UnixMilli, inclusive
+	End   int64 // UnixMilli, exclusive
+}
+
+// Redenomination is a balance split waiting for multisig approvals
+type Redenomination struct {
+	Id          string
+	Proposer    string
+	Numerator   *big.Int
+	Denominator *big.Int
+	Decimals    string // new decimals, empty keeps the current ones
+	Reason      string
+	Approvals   []string
+}
+
+// RewardAccount is the reward state of an account for one reward token
+type RewardAccount struct {
+	Paid *big.Int // reward per token already accrued
+	Owed *big.Int // accrued and unclaimed rewards
+}
+
+// Emission is a schedule minting Rate per Period to Recipient between Start and End (UnixMilli)
+type Emission struct {
+	Recipient       string
+	Rate            string
+	Period          int64 `json:",omitempty"` // defaults to one day
+	Start           int64
+	End             int64 `json:",omitempty"` // 0 emits without end
+	HalvingInterval int64 `json:",omitempty"` // the rate halves every interval from Start, 0 disables halving
+}
+
+// IdempotencyRecord is the result of a message sent with an X-Idempotency-Key
+type IdempotencyRecord struct {
+	Sender     string
+	Key        string
+	Action     string
+	ParamsHash string `json:",omitempty"` // hex SHA-256 of the params a reuse of the key must repeat
+	Timestamp  int64  // UnixMilli
+	Messages   string // JSON of the result messages addressed to the sender
+}
+
+// Twab is the time-weighted balance history of an account or of the total supply
+type Twab struct {
+	Since        int64             `json:"since"` // unix seconds from which the history is complete
+	Observations []TwabObservation `json:"observations"`
+}
+
+// TwabObservation is checkpointed compactly as [timestamp, cumulative, balance]
+type TwabObservation struct {
+	Timestamp  int64    // unix seconds
+	Cumulative *big.Int // balance-seconds accumulated up to Timestamp
+	Balance    *big.Int // balance from Timestamp on
+}
+
+func (o TwabObservation) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{o.Timestamp, o.Cumulative, o.Balance})
+}
+
+func (o *TwabObservation) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return errors.New("invalid twab observation")
+	}
+	if err := json.Unmarshal(fields[0], &o.Timestamp); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &o.Cumulative); err != nil {
+		return err
+	}
+	return json.Unmarshal(fields[2], &o.Balance)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"math/big"
 	"testing"
+	"time"
 )
 
 var (
@@ -71,3 +72,217 @@ func Test_Basic_Token_Transfer(t *testing.T) {
 	bal = getBalanceByCache(bToken, addr02)
 	assert.Equal(t, amt02, bal)
 }
+
+func Test_Basic_Token_Hold(t *testing.T) {
+	acc := hysdk.GetAddress()
+	basicTokenMint(bToken, acc, "1000")
+	initialBal := getBalanceByCache(bToken, acc)
+
+	// Approve and place a hold on the own balance
+	approveHold(bToken, acc, "300")
+	holdId := placeHold(bToken, acc, "300")
+
+	// Held funds are reported by Balance and can't be spent
+	tags := balanceTags(bToken, acc)
+	assert.Equal(t, initialBal.String(), tags["Balance"])
+	assert.Equal(t, "300", tags["Held"])
+	recipient := "0x6d2e03b7EfFEae98BD302A9F836D0d6Ab0002766"
+	spendable := new(big.Int).Sub(initialBal, big.NewInt(300))
+	overdraft := new(big.Int).Add(spendable, big.NewInt(1))
+	assert.Equal(t, "err_insufficient_balance", tryTransfer(bToken, recipient, overdraft.String()))
+
+	// Capture part of the hold, the remainder is released
+	initialRecipientBal := getBalanceByCache(bToken, recipient)
+	captureHold(bToken, holdId, "120", recipient)
+
+	bal := getBalanceByCache(bToken, acc)
+	assert.Equal(t, new(big.Int).Sub(initialBal, big.NewInt(120)), bal)
+
+	bal = getBalanceByCache(bToken, recipient)
+	assert.Equal(t, new(big.Int).Add(initialRecipientBal, big.NewInt(120)), bal)
+	assert.Equal(t, "0", balanceTags(bToken, acc)["Held"])
+}
+
+func Test_Basic_Token_FreezeAndForceTransfer(t *testing.T) {
+	acc := hysdk.GetAddress()
+	account := "0x4002ED1a1410aF1b4930cF6c479ae373dEbD6223"
+	basicTokenMint(bToken, account, "500")
+
+	freezeAccount(bToken, account, "court order 42")
+	assert.Contains(t, getFrozenByCache(bToken), account)
+
+	// Claw back part of the frozen balance
+	initialBal := getBalanceByCache(bToken, acc)
+	forceTransfer(bToken, account, acc, "200", "court order 42")
+
+	bal := getBalanceByCache(bToken, account)
+	assert.Equal(t, big.NewInt(300), bal)
+
+	bal = getBalanceByCache(bToken, acc)
+	assert.Equal(t, new(big.Int).Add(initialBal, big.NewInt(200)), bal)
+}
+
+func Test_Basic_Token_ForceTransferHolds(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	recipient := "0x6d2e03b7EfFEae98BD302A9F836D0d6Ab0002766"
+	basicTokenMint(token, acc, "500")
+	approveHold(token, acc, "300")
+	placeHold(token, acc, "300")
+
+	// A clawback into the held funds shrinks the hold to the remaining balance
+	forceTransfer(token, acc, recipient, "400", "court order 43")
+	tags := balanceTags(token, acc)
+	assert.Equal(t, "100", tags["Balance"])
+	assert.Equal(t, "100", tags["Held"])
+	assert.Equal(t, big.NewInt(400), getBalanceByCache(token, recipient))
+}
+
+func Test_Basic_Token_Policy(t *testing.T) {
+	// Use a fresh token, the owner of bToken has been handed over
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	basicTokenMint(token, acc, "5000")
+
+	setPolicy(token, `{"MaxTransferAmount":"1000"}`)
+	policy := getPolicyByCache(token)
+	assert.Equal(t, "1000", policy.MaxTransferAmount)
+
+	// Transfers above the max amount are rejected
+	recipient := "0x6d2e03b7EfFEae98BD302A9F836D0d6Ab0002766"
+	transfer(token, recipient, "2000")
+	assert.Equal(t, big.NewInt(5000), getBalanceByCache(token, acc))
+
+	transfer(token, recipient, "1000")
+	assert.Equal(t, big.NewInt(4000), getBalanceByCache(token, acc))
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(token, recipient))
+
+	// Clear the policy
+	setPolicy(token, `{}`)
+	assert.Equal(t, "", getPolicyByCache(token).MaxTransferAmount)
+}
+
+func Test_Basic_Token_Redenominate(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	basicTokenMint(token, acc, "1001")
+	basicTokenMint(token, addr01, "999")
+
+	// Divide by 10 and drop one decimal, remainders are rounded down
+	redenominate(token, "1", "10", "11")
+
+	assert.Equal(t, big.NewInt(100), getBalanceByCache(token, acc))
+	assert.Equal(t, big.NewInt(99), getBalanceByCache(token, addr01))
+	assert.Equal(t, big.NewInt(199), getTotalSupplyByCache(token))
+	assert.Equal(t, "11", getBasicTokenInfoByCache(token).Decimals)
+}
+
+func Test_Basic_Token_RedenominateMultisig(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	basicTokenMint(token, acc, "1000")
+	assert.Equal(t, "", setRedenominateMultisig(token, `["`+acc+`","`+addr01+`"]`, "1"))
+
+	// Once configured, the owner can't disable the multisig
+	assert.Equal(t, "err_invalid_multisig", setRedenominateMultisig(token, "", "0"))
+
+	// A signer change drops the pending proposal
+	proposalId := proposeRedenomination(token, "2", "1")
+	assert.NotEqual(t, "", proposalId)
+	assert.Equal(t, "", setRedenominateMultisig(token, `["`+acc+`"]`, "1"))
+	assert.Equal(t, "err_proposal_not_found", approveRedenomination(token, proposalId))
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(token, acc))
+
+	proposalId = proposeRedenomination(token, "2", "1")
+	assert.Equal(t, "", approveRedenomination(token, proposalId))
+	assert.Equal(t, big.NewInt(2000), getBalanceByCache(token, acc))
+}
+
+func Test_Basic_Token_Rewards(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	basicTokenMint(token, acc, "300")
+	basicTokenMint(token, addr01, "100")
+
+	// The pool leaves acc before sharing, acc holds 200 of the 300 eligible tokens
+	distribute(token, "100")
+	assert.Equal(t, big.NewInt(100), getBalanceByCache(token, token))
+
+	claimRewards(token, "")
+	assert.Equal(t, big.NewInt(266), getBalanceByCache(token, acc))
+	assert.Equal(t, big.NewInt(34), getBalanceByCache(token, token))
+}
+
+func Test_Basic_Token_RewardTokens(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	rewardToken := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	basicTokenMint(token, addr01, "100")
+	basicTokenMint(rewardToken, acc, "1000")
+
+	// A pool of a token that isn't allowed is sent back
+	distributeToken(rewardToken, token, "100")
+	time.Sleep(2 * time.Second)
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(rewardToken, acc))
+	assert.Equal(t, big.NewInt(0), getBalanceByCache(rewardToken, token))
+
+	assert.Equal(t, "err_invalid_reward_tokens", setRewardTokens(token, `["`+token+`"]`))
+	assert.Equal(t, "err_invalid_reward_tokens", setRewardTokens(token, `["`+rewardToken+`","`+rewardToken+`"]`))
+	assert.Equal(t, "", setRewardTokens(token, `["`+rewardToken+`"]`))
+
+	distributeToken(rewardToken, token, "100")
+	time.Sleep(2 * time.Second)
+	assert.Equal(t, big.NewInt(900), getBalanceByCache(rewardToken, acc))
+	assert.Equal(t, big.NewInt(100), getBalanceByCache(rewardToken, token))
+}
+
+func Test_Basic_Token_Twab(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	basicTokenMint(token, acc, "100")
+	time.Sleep(2 * time.Second)
+
+	// Nothing was held before the first mint, so the history is complete since 0
+	twab(token, "TWAB", acc, "0")
+	twab(token, "Total-Supply-TWAB", "", "0")
+}
+
+func Test_Basic_Token_Emission(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, "1000")
+	acc := hysdk.GetAddress()
+
+	// One million per second, capped by the max supply
+	setEmission(token, `{"Recipient":"`+acc+`","Rate":"1000000","Period":1000}`)
+	assert.Equal(t, "1000000", getEmissionByCache(token).Rate)
+	time.Sleep(2 * time.Second)
+
+	emit(token)
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(token, acc))
+	assert.Equal(t, big.NewInt(1000), getTotalSupplyByCache(token))
+
+	// Only the minted part counts as emitted, the rest stays pending
+	assert.Equal(t, "1000", getEmissionByCache(token).Emitted)
+}
+
+func Test_Basic_Token_IdempotencyKey(t *testing.T) {
+	token := basicToken(nameB, tickerB, decimalsB, maxSupply)
+	acc := hysdk.GetAddress()
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	basicTokenMint(token, acc, "100")
+
+	// The retry with the same key is not applied again
+	transferWithIdempotencyKey(token, addr01, "30", "payment-1")
+	transferWithIdempotencyKey(token, addr01, "30", "payment-1")
+	assert.Equal(t, big.NewInt(30), getBalanceByCache(token, addr01))
+
+	// A reuse with other params is rejected instead of replayed
+	assert.Equal(t, "err_idempotency_key_reused", tryTransferWithIdempotencyKey(token, addr01, "40", "payment-1"))
+
+	transferWithIdempotencyKey(token, addr01, "30", "payment-2")
+	assert.Equal(t, big.NewInt(60), getBalanceByCache(token, addr01))
+	assert.Equal(t, big.NewInt(40), getBalanceByCache(token, acc))
+}
@@ -1,9 +1,14 @@
 package test
 
 import (
+	"encoding/json"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/aox-labs/hymx-vmtoken/crosschain"
+	"github.com/aox-labs/hymx-vmtoken/schema"
+	vmmSchema "github.com/hymatrix/hymx/vmm/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +46,7 @@ func Test_Cc_Token_CrossChainMint(t *testing.T) {
 	mintTxHash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
 
 	// Perform cross-chain mint
+	registerSourceToken(cToken, sourceTokenId, sourceChainType)
 	crossChainMint(cToken, recipient, quantity, sourceChainType, sourceTokenId, mintTxHash)
 
 	// Verify balance increased
@@ -73,6 +79,7 @@ func Test_Cc_Token_CrossChainMint_DuplicateTxHash(t *testing.T) {
 	mintTxHash := "0x9999999999999999999999999999999999999999999999999999999999999999"
 
 	// First mint should succeed
+	registerSourceToken(cToken, sourceTokenId, sourceChainType)
 	crossChainMint(cToken, recipient, quantity, sourceChainType, sourceTokenId, mintTxHash)
 
 	// Verify first mint succeeded
@@ -101,6 +108,7 @@ func Test_Cc_Token_CrossChainMint_MultipleMints(t *testing.T) {
 	// First mint
 	quantity1 := "2000000"
 	mintTxHash1 := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	registerSourceToken(cToken, sourceTokenId, sourceChainType)
 	crossChainMint(cToken, recipient, quantity1, sourceChainType, sourceTokenId, mintTxHash1)
 
 	// Verify after first mint
@@ -137,6 +145,7 @@ func Test_Cc_Token_Transfer(t *testing.T) {
 	mintTxHash1 := "0x1111111111111111111111111111111111111111111111111111111111111111"
 
 	// Mint tokens via cross-chain mint
+	registerSourceToken(cToken, sourceTokenId, sourceChainType)
 	crossChainMint(cToken, acc, quantity.String(), sourceChainType, sourceTokenId, mintTxHash1)
 
 	// Verify SourceTokenChains and SourceLockAmounts updated after mint
@@ -183,6 +192,7 @@ func Test_Cc_Token_CrossChainBurn(t *testing.T) {
 	setCcTokenParams(cToken, "", feeRecipient, "", "")
 
 	// Mint tokens via cross-chain mint (this establishes the source token chain mapping)
+	registerSourceToken(cToken, targetTokenId, chainType)
 	crossChainMint(cToken, acc, mintQuantity, chainType, targetTokenId, mintTxHash)
 
 	// Verify SourceTokenChains and SourceLockAmounts after mint
@@ -200,6 +210,7 @@ func Test_Cc_Token_CrossChainBurn(t *testing.T) {
 	// Get initial balances and total supply
 	initialBal := getBalanceByCache(cToken, acc)
 	initialFeeRecipientBal := getBalanceByCache(cToken, feeRecipient)
+	initialFees := getAvailableFeesByCache(cToken, chainType)
 	initialTotal := getTotalSupplyByCache(cToken)
 
 	// Perform cross-chain burn operation
@@ -209,6 +220,7 @@ func Test_Cc_Token_CrossChainBurn(t *testing.T) {
 	// Get balances after burn
 	finalBal := getBalanceByCache(cToken, acc)
 	finalFeeRecipientBal := getBalanceByCache(cToken, feeRecipient)
+	finalFees := getAvailableFeesByCache(cToken, chainType)
 	finalTotal := getTotalSupplyByCache(cToken)
 
 	// Calculate expected values (burnQuantity - burnFee)
@@ -220,12 +232,12 @@ func Test_Cc_Token_CrossChainBurn(t *testing.T) {
 	expectedBal := new(big.Int).Sub(initialBal, burnAmount)
 	assert.Equal(t, expectedBal, finalBal)
 
-	// Verify fee recipient received the burn fee
-	expectedFeeRecipientBal := new(big.Int).Add(initialFeeRecipientBal, burnFee)
-	assert.Equal(t, expectedFeeRecipientBal, finalFeeRecipientBal)
+	// Verify the burn fee accrued in the fee ledger, not to the fee recipient
+	assert.Equal(t, initialFeeRecipientBal, finalFeeRecipientBal)
+	assert.Equal(t, new(big.Int).Add(initialFees, burnFee), finalFees)
 
-	// Verify total supply decreased by net burn (quantity - fee)
-	expectedTotal := new(big.Int).Sub(initialTotal, expectedBurn)
+	// Verify total supply decreased by the full amount, the fee left the supply
+	expectedTotal := new(big.Int).Sub(initialTotal, burnAmount)
 	assert.Equal(t, expectedTotal, finalTotal)
 
 	// Verify SourceLockAmounts decreased after burn
@@ -251,6 +263,7 @@ func Test_Cc_Token_CrossChainBurn_WithRecipient(t *testing.T) {
 	setCcTokenParams(cToken, "", feeRecipient, "", "")
 
 	// Mint tokens via cross-chain mint
+	registerSourceToken(cToken, targetTokenId, chainType)
 	crossChainMint(cToken, acc, mintQuantity, chainType, targetTokenId, mintTxHash)
 
 	// Verify SourceTokenChains and SourceLockAmounts after mint
@@ -268,6 +281,7 @@ func Test_Cc_Token_CrossChainBurn_WithRecipient(t *testing.T) {
 	// Get initial balances and total supply
 	initialBal := getBalanceByCache(cToken, acc)
 	initialFeeRecipientBal := getBalanceByCache(cToken, feeRecipient)
+	initialFees := getAvailableFeesByCache(cToken, chainType)
 	initialTotal := getTotalSupplyByCache(cToken)
 
 	// Perform cross-chain burn with recipient hint
@@ -278,6 +292,7 @@ func Test_Cc_Token_CrossChainBurn_WithRecipient(t *testing.T) {
 	// Get balances after burn
 	finalBal := getBalanceByCache(cToken, acc)
 	finalFeeRecipientBal := getBalanceByCache(cToken, feeRecipient)
+	finalFees := getAvailableFeesByCache(cToken, chainType)
 	finalTotal := getTotalSupplyByCache(cToken)
 
 	// Calculate expected values
@@ -289,12 +304,12 @@ func Test_Cc_Token_CrossChainBurn_WithRecipient(t *testing.T) {
 	expectedBal := new(big.Int).Sub(initialBal, burnAmount)
 	assert.Equal(t, expectedBal, finalBal)
 
-	// Verify fee recipient received the burn fee
-	expectedFeeRecipientBal := new(big.Int).Add(initialFeeRecipientBal, burnFee)
-	assert.Equal(t, expectedFeeRecipientBal, finalFeeRecipientBal)
+	// Verify the burn fee accrued in the fee ledger, not to the fee recipient
+	assert.Equal(t, initialFeeRecipientBal, finalFeeRecipientBal)
+	assert.Equal(t, new(big.Int).Add(initialFees, burnFee), finalFees)
 
-	// Verify total supply decreased by net burn
-	expectedTotal := new(big.Int).Sub(initialTotal, expectedBurn)
+	// Verify total supply decreased by the full amount, the fee left the supply
+	expectedTotal := new(big.Int).Sub(initialTotal, burnAmount)
 	assert.Equal(t, expectedTotal, finalTotal)
 
 	// Verify SourceLockAmounts decreased after burn
@@ -326,3 +341,538 @@ func Test_Cc_Token_SetTokenOwner(t *testing.T) {
 	info := getCcTokenInfoByCache(cToken)
 	assert.Equal(t, newOwner, info.Owner)
 }
+
+func Test_Cc_Token_CrossChainMint_Retention(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	recipient := "0xe688b84b23f322a994A53dbF8E15FA82CDB71127"
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	setMintRecordRetention(token, "", "10")
+
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "100"))
+	assert.Equal(t, "err_repeat_mint", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "100"))
+
+	// Height 200 prunes the record at 100, older heights are rejected
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(2), "200"))
+	assert.Equal(t, "err_mint_below_retention", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "100"))
+	assert.Equal(t, "err_mint_below_retention", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(3), "190"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(4), "191"))
+	assert.Equal(t, big.NewInt(300), getBalanceByCache(token, recipient))
+
+	// A mistyped height rejects the later mints until the watermark is reset
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(5), "1000000000000000"))
+	assert.Equal(t, "err_mint_below_retention", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(6), "300"))
+	assert.Equal(t, "err_invalid_block_height", resetMintWatermark(token, "ethereum", "x"))
+	assert.Equal(t, "", resetMintWatermark(token, "ethereum", "300"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(6), "300"))
+	assert.Equal(t, "err_repeat_mint", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(5), "300"))
+	assert.Equal(t, big.NewInt(500), getBalanceByCache(token, recipient))
+}
+
+func Test_Cc_Token_CrossChainMint_AgeRetention(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	recipient := "0xe688b84b23f322a994A53dbF8E15FA82CDB71127"
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	assert.Equal(t, "err_invalid_mint_retention", setMintRecordRetentionErr(token, "1", ""))
+	setMintRecordRetention(token, "1", "1000")
+
+	// Every later message prunes the earlier records by age, their tx hashes stay archived
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "100"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(2), "200"))
+	assert.Equal(t, 1, len(getMintRecordsByCache(token)))
+	assert.Equal(t, "err_repeat_mint", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "100"))
+	assert.Equal(t, "err_repeat_mint", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "300"))
+
+	// Age pruning raises no watermark, a deposit of a lower block still mints
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(3), "150"))
+	assert.Equal(t, "err_repeat_mint", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(2), "200"))
+
+	// The block watermark compacts the archived hashes
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(4), "1200"))
+	assert.Equal(t, "err_mint_below_retention", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "100"))
+	assert.Equal(t, big.NewInt(400), getBalanceByCache(token, recipient))
+}
+
+func Test_Cc_Token_BurnRecordRetention(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	setCcTokenBurnFee(token, "ethereum", burnFeeC)
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	crossChainMint(token, acc, "1000", "ethereum", sourceTokenId, txHash(1))
+	crossChainBurn(token, "300", sourceTokenId, "")
+	burnComplete(token, getBurnRecordsByCache(token)[0].BurnTxHash, txHash(100))
+
+	// The mint retention leaves the burn history alone
+	setMintRecordRetention(token, "1", "1000")
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "1000", "ethereum", sourceTokenId, txHash(2), "1"))
+	assert.Equal(t, 1, len(getMintRecordsByCache(token)))
+	assert.Equal(t, 1, len(getBurnRecordsByCache(token)))
+
+	setBurnRecordMaxAge(token, "1")
+	assert.Equal(t, 0, len(getBurnRecordsByCache(token)))
+	assert.Equal(t, "1", getCcTokenInfoByCache(token).BurnRecordMaxAge)
+}
+
+func Test_Cc_Token_MintAndBurnRecords(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	setCcTokenBurnFee(token, "ethereum", burnFeeC)
+
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	crossChainMint(token, acc, "1000", "ethereum", sourceTokenId, txHash(10))
+	crossChainBurn(token, "500", sourceTokenId, "")
+
+	mintRecords := getMintRecordsByCache(token)
+	assert.Equal(t, 1, len(mintRecords))
+	assert.Equal(t, txHash(10), mintRecords[0].MintTxHash)
+	assert.Equal(t, acc, mintRecords[0].Recipient)
+	assert.Equal(t, "1000", mintRecords[0].Quantity)
+	assert.Equal(t, "ethereum", mintRecords[0].SourceChainType)
+
+	burnRecords := getBurnRecordsByCache(token)
+	assert.Equal(t, 1, len(burnRecords))
+	assert.Equal(t, acc, burnRecords[0].Sender)
+	assert.Equal(t, "400", burnRecords[0].Quantity)
+	assert.Equal(t, burnFeeC, burnRecords[0].Fee)
+	assert.Equal(t, sourceTokenId, burnRecords[0].TargetTokenId)
+}
+
+func Test_Cc_Token_BurnLifecycle(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	setCcTokenBurnFee(token, "ethereum", burnFeeC)
+	setCcTokenParams(token, "", feeRecipientC, "", "")
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	crossChainMint(token, acc, "1000", "ethereum", sourceTokenId, txHash(11))
+
+	// A completed burn stays burnt
+	crossChainBurn(token, "300", sourceTokenId, "")
+	burnTxHash := getBurnRecordsByCache(token)[0].BurnTxHash
+	assert.Equal(t, "err_invalid_target_tx_hash", tryBurnComplete(token, burnTxHash, "0xabc"))
+	burnComplete(token, burnTxHash, strings.ToUpper(txHash(12)))
+	record := getBurnRecordsByCache(token)[0]
+	assert.Equal(t, "completed", record.Status)
+	assert.Equal(t, txHash(12), record.TargetTxHash)
+	assert.Equal(t, big.NewInt(700), getTotalSupplyByCache(token))
+
+	// A refunded burn with its fee restores the balance, the supply and the lock amount
+	crossChainBurn(token, "300", sourceTokenId, "")
+	burnTxHash = getBurnRecordsByCache(token)[0].BurnTxHash
+	burnRefund(token, burnTxHash, "true")
+	assert.Equal(t, "refunded", getBurnRecordsByCache(token)[0].Status)
+	assert.Equal(t, big.NewInt(700), getBalanceByCache(token, acc))
+	assert.Equal(t, big.NewInt(100), getAvailableFeesByCache(token, "ethereum"))
+	assert.Equal(t, big.NewInt(700), getTotalSupplyByCache(token))
+	lockAmounts := parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, big.NewInt(800), lockAmounts["ethereum:"+sourceTokenId])
+}
+
+func Test_Cc_Token_SourceTokenRegistry(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	recipient := "0xe688b84b23f322a994A53dbF8E15FA82CDB71127"
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+
+	// Unregistered sources can't be minted
+	assert.Equal(t, "err_unregistered_source_token", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "1"))
+
+	// A wrong chain type can be fixed while nothing is locked
+	assert.Equal(t, "", sourceTokenAction(token, "Register-Source-Token", sourceTokenId, "bsc"))
+	assert.Equal(t, "err_source_token_registered", sourceTokenAction(token, "Register-Source-Token", sourceTokenId, "ethereum"))
+	assert.Equal(t, "", sourceTokenAction(token, "Update-Source-Token", sourceTokenId, "ethereum"))
+	assert.Equal(t, "ethereum", parseSourceTokenChains(getCcTokenInfoByCache(token).SourceTokenChains)[sourceTokenId])
+
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), "1"))
+	assert.Equal(t, "err_source_token_locked", sourceTokenAction(token, "Update-Source-Token", sourceTokenId, "bsc"))
+
+	assert.Equal(t, "", sourceTokenAction(token, "Disable-Source-Token", sourceTokenId, ""))
+	assert.Equal(t, "err_source_token_disabled", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(2), "2"))
+}
+
+func Test_Cc_Token_DecimalConversion(t *testing.T) {
+	// The wrapped token has 6 decimals
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	setCcTokenParams(token, "", feeRecipientC, "", "")
+
+	// 18 decimals source: mints are scaled down, the dust is rejected by default
+	ethToken := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceTokenWithDecimals(token, ethToken, "ethereum", "18")
+	setCcTokenBurnFee(token, "ethereum", "0")
+	assert.Equal(t, "err_dust_amount", crossChainMintAtHeight(token, acc, "1000000000000000001", "ethereum", ethToken, txHash(1), ""))
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "1000000000000000000", "ethereum", ethToken, txHash(2), ""))
+	assert.Equal(t, big.NewInt(1000000), getBalanceByCache(token, acc))
+
+	// The wrapped decimals can't rescale the existing balances and locks
+	assert.Equal(t, "err_decimals_locked", setCcTokenDecimals(token, "8"))
+	assert.Equal(t, decimalsC, getCcTokenInfoByCache(token).Decimals)
+
+	// Lock amounts are in source units
+	crossChainBurn(token, "500000", ethToken, "")
+	lockAmounts := parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, mustParseBigInt("500000000000000000"), lockAmounts["ethereum:"+ethToken])
+
+	// 2 decimals source: burns are scaled down, the dust goes to the fee recipient with the fee policy
+	bscToken := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	registerSourceTokenWithDecimals(token, bscToken, "bsc", "2")
+	setCcTokenBurnFee(token, "bsc", "0")
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "150", "bsc", bscToken, txHash(3), ""))
+	assert.Equal(t, "err_dust_amount", tryCrossChainBurn(token, "1234567", bscToken))
+	setDustPolicy(token, "fee")
+	assert.Equal(t, "", tryCrossChainBurn(token, "1234567", bscToken))
+	assert.Equal(t, big.NewInt(4567), getAvailableFeesByCache(token, "bsc"))
+	lockAmounts = parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, big.NewInt(27), lockAmounts["bsc:"+bscToken])
+}
+
+func Test_Cc_Token_AttestedMint(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	recipient := "0xe688b84b23f322a994A53dbF8E15FA82CDB71127"
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, sourceTokenId, "ethereum")
+
+	// A single relayer at threshold 1 mints on its attestation
+	setRelayers(token, `["`+acc+`"]`, "1")
+	assert.Equal(t, "err_attestation_required", crossChainMintAtHeight(token, recipient, "100", "ethereum", sourceTokenId, txHash(1), ""))
+	assert.Equal(t, "", attestMint(token, recipient, "100", "ethereum", sourceTokenId, txHash(1)))
+	assert.Equal(t, big.NewInt(100), getBalanceByCache(token, recipient))
+	assert.Equal(t, "err_repeat_mint", attestMint(token, recipient, "100", "ethereum", sourceTokenId, txHash(1)))
+
+	// At threshold 2 a conflicting attestation freezes the mint
+	setRelayers(token, `["`+acc+`","0x6d2e03b7EfFEae98BD302A9F836D0d6Ab0002766"]`, "2")
+	assert.Equal(t, "", attestMint(token, recipient, "100", "ethereum", sourceTokenId, txHash(2)))
+	assert.Equal(t, "err_already_attested", attestMint(token, recipient, "100", "ethereum", sourceTokenId, txHash(2)))
+	assert.Equal(t, "", attestMint(token, recipient, "200", "ethereum", sourceTokenId, txHash(2)))
+	assert.Equal(t, "err_mint_attestation_frozen", attestMint(token, recipient, "100", "ethereum", sourceTokenId, txHash(2)))
+	assert.Equal(t, big.NewInt(100), getBalanceByCache(token, recipient))
+}
+
+func Test_Cc_Token_BurnFeeModels(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	setCcTokenParams(token, "", feeRecipientC, "", "")
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "100000", "ethereum", sourceTokenId, txHash(1), ""))
+
+	// Models are strictly validated
+	assert.Equal(t, "err_invalid_fee_model", setBurnFeeModels(token, `{"ethereum":{"Bps":10001}}`))
+	assert.Equal(t, "err_invalid_fee_model", setBurnFeeModels(token, `{"ethereum":{"Percent":1}}`))
+	assert.Equal(t, "err_invalid_fee_model", setBurnFeeModels(token, `{"ethereum":{"Min":"100","Max":"10"}}`))
+
+	// 1% with a minimum of 50
+	assert.Equal(t, "", setBurnFeeModels(token, `{"ethereum":{"Bps":100,"Min":"50"}}`))
+	crossChainBurn(token, "1000", sourceTokenId, "")
+	assert.Equal(t, big.NewInt(50), getAvailableFeesByCache(token, "ethereum"))
+	crossChainBurn(token, "10000", sourceTokenId, "")
+	assert.Equal(t, big.NewInt(150), getAvailableFeesByCache(token, "ethereum"))
+
+	// The model of the target token overrides the chain type model, tiers override the base fee
+	assert.Equal(t, "", setBurnFeeModels(token, `{"ethereum:`+sourceTokenId+`":{"Flat":"7","Tiers":[{"From":"5000","Flat":"3"}]}}`))
+	crossChainBurn(token, "1000", sourceTokenId, "")
+	assert.Equal(t, big.NewInt(157), getAvailableFeesByCache(token, "ethereum"))
+	crossChainBurn(token, "5000", sourceTokenId, "")
+	assert.Equal(t, big.NewInt(160), getAvailableFeesByCache(token, "ethereum"))
+	assert.Contains(t, getCcTokenInfoByCache(token).BurnFeeModels, `"Bps":100`)
+}
+
+func Test_Cc_Token_QuoteBurn(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	setCcTokenBurnFee(token, "ethereum", burnFeeC)
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "1000", "ethereum", sourceTokenId, txHash(1), ""))
+
+	quote := quoteBurn(token, "500", sourceTokenId)
+	assert.Equal(t, "true", quote["Ok"])
+	assert.Equal(t, "ethereum", quote["TargetChainType"])
+	assert.Equal(t, burnFeeC, quote["Fee"])
+	assert.Equal(t, "400", quote["NetQuantity"])
+	assert.Equal(t, "1000", quote["LockAmount"])
+
+	// The quote reports the error of the burn without changing state
+	quote = quoteBurn(token, "2000", sourceTokenId)
+	assert.Equal(t, "false", quote["Ok"])
+	assert.Equal(t, "err_insufficient_lock_amount", quote["Error"])
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(token, acc))
+}
+
+func Test_Cc_Token_MintFees(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	recipient := "0xe688b84b23f322a994A53dbF8E15FA82CDB71127"
+	setCcTokenParams(token, "", feeRecipientC, "", "")
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, sourceTokenId, "ethereum")
+
+	// 1% mint fee: the recipient gets the net amount, the lock rises by the gross amount
+	assert.Equal(t, "", setMintFeeModels(token, `{"ethereum":{"Bps":100}}`))
+	assert.Equal(t, "", crossChainMintAtHeight(token, recipient, "10000", "ethereum", sourceTokenId, txHash(1), ""))
+	assert.Equal(t, big.NewInt(9900), getBalanceByCache(token, recipient))
+	assert.Equal(t, big.NewInt(100), getAvailableFeesByCache(token, "ethereum"))
+	assert.Equal(t, big.NewInt(9900), getTotalSupplyByCache(token))
+	lockAmounts := parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, big.NewInt(10000), lockAmounts["ethereum:"+sourceTokenId])
+
+	// A fee above the amount is rejected
+	assert.Equal(t, "", setMintFeeModels(token, `{"ethereum:`+sourceTokenId+`":{"Flat":"50"}}`))
+	assert.Equal(t, "err_incorrect_quantity", crossChainMintAtHeight(token, recipient, "10", "ethereum", sourceTokenId, txHash(2), ""))
+}
+
+func Test_Cc_Token_FeeLedger(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	sourceTokenId := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, sourceTokenId, "ethereum")
+	setCcTokenBurnFee(token, "ethereum", "101")
+	crossChainMint(token, acc, "1000", "ethereum", sourceTokenId, txHash(1))
+	crossChainBurn(token, "500", sourceTokenId, "")
+	assert.Equal(t, big.NewInt(101), getAvailableFeesByCache(token, "ethereum"))
+
+	// 70/30 shares, the rounding remainder stays accrued
+	shareA := "0x6d2e03b7EfFEae98BD302A9F836D0d6Ab0002766"
+	shareB := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	assert.Equal(t, "err_invalid_fee_shares", setFeeShares(token, `[{"Recipient":"`+shareA+`","Share":0}]`))
+	assert.Equal(t, "", setFeeShares(token, `[{"Recipient":"`+shareA+`","Share":70},{"Recipient":"`+shareB+`","Share":30}]`))
+	assert.Equal(t, "err_insufficient_fees", withdrawFees(token, "ethereum", "200"))
+	assert.Equal(t, "", withdrawFees(token, "", ""))
+	assert.Equal(t, big.NewInt(70), getBalanceByCache(token, shareA))
+	assert.Equal(t, big.NewInt(30), getBalanceByCache(token, shareB))
+	assert.Equal(t, big.NewInt(1), getAvailableFeesByCache(token, "ethereum"))
+
+	// Withdrawn fees are back in the supply
+	assert.Equal(t, big.NewInt(600), getTotalSupplyByCache(token))
+}
+
+func Test_Cc_Token_BurnRouting(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	tokenA := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	tokenB := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	registerSourceToken(token, tokenA, "ethereum")
+	registerSourceToken(token, tokenB, "ethereum")
+	setCcTokenBurnFee(token, "ethereum", "0")
+	crossChainMint(token, acc, "1000", "ethereum", tokenA, txHash(1))
+	crossChainMint(token, acc, "500", "ethereum", tokenB, txHash(2))
+	assert.Equal(t, "err_no_burn_route", burnToChain(token, "100", "bsc"))
+
+	// By default the source token with the largest lock amount takes the burn
+	assert.Equal(t, "", burnToChain(token, "800", "ethereum"))
+	lockAmounts := parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, big.NewInt(200), lockAmounts["ethereum:"+tokenA])
+	assert.Equal(t, big.NewInt(500), lockAmounts["ethereum:"+tokenB])
+
+	// The preferred strategy tries the preferred source tokens first
+	assert.Equal(t, "err_invalid_burn_route", setBurnRoutes(token, `{"ethereum":{"Strategy":"random"}}`))
+	assert.Equal(t, "", setBurnRoutes(token, `{"ethereum":{"Strategy":"preferred","Preferred":["`+tokenA+`"]}}`))
+	assert.Equal(t, tokenA, quoteBurnToChain(token, "100", "ethereum")["TargetTokenId"])
+
+	// The split strategy spreads the burn with one record per source token
+	assert.Equal(t, "", setBurnRoutes(token, `{"ethereum":{"Strategy":"split"}}`))
+	quote := quoteBurnToChain(token, "600", "ethereum")
+	assert.Equal(t, "2", quote["Legs"])
+	assert.Equal(t, "", burnToChain(token, "600", "ethereum"))
+	lockAmounts = parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, big.NewInt(100), lockAmounts["ethereum:"+tokenA])
+	assert.Equal(t, big.NewInt(0), lockAmounts["ethereum:"+tokenB])
+	records := getBurnRecordsByCache(token)
+	assert.Equal(t, tokenA, records[0].TargetTokenId)
+	assert.Equal(t, tokenB, records[1].TargetTokenId)
+	assert.Equal(t, "err_insufficient_lock_amount", burnToChain(token, "200", "ethereum"))
+}
+
+func Test_Cc_Token_LockCorrections(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	tokenA := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	tokenB := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	registerSourceToken(token, tokenA, "ethereum")
+	registerSourceTokenWithDecimals(token, tokenB, "bsc", "8")
+	crossChainMint(token, acc, "1000", "ethereum", tokenA, txHash(1))
+
+	// Corrections need a reason and a reference
+	assert.Equal(t, "err_missing_reference", lockCorrection(token, "Adjust-Lock-Amount", map[string]string{
+		"SourceTokenId": tokenA, "LockAmount": "900", "Reason": "incident",
+	}))
+	assert.Equal(t, "", lockCorrection(token, "Adjust-Lock-Amount", map[string]string{
+		"SourceTokenId": tokenA, "LockAmount": "900", "Reason": "incident", "Reference": "INC-1",
+	}))
+
+	// Moves convert between the source decimals
+	assert.Equal(t, "", lockCorrection(token, "Move-Lock-Amount", map[string]string{
+		"SourceTokenId": tokenA, "ToSourceTokenId": tokenB, "Quantity": "400", "Reason": "migration", "Reference": "MIG-1",
+	}))
+	lockAmounts := parseSourceLockAmounts(getCcTokenInfoByCache(token).SourceLockAmounts)
+	assert.Equal(t, big.NewInt(500), lockAmounts["ethereum:"+tokenA])
+	assert.Equal(t, big.NewInt(40000), lockAmounts["bsc:"+tokenB])
+	assert.Equal(t, "err_insufficient_lock_amount", lockCorrection(token, "Move-Lock-Amount", map[string]string{
+		"SourceTokenId": tokenA, "ToSourceTokenId": tokenB, "Quantity": "600", "Reason": "migration", "Reference": "MIG-2",
+	}))
+}
+
+func Test_Cc_Token_ReserveReports(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	tokenA := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, tokenA, "ethereum")
+	crossChainMint(token, acc, "1000", "ethereum", tokenA, txHash(1))
+
+	// Only attestors can report
+	assert.Equal(t, "err_incorrect_reserve_attestor", reserveReport(token, tokenA, "ethereum", "1000", "100"))
+	assert.Equal(t, "", setReserveAttestors(token, `["`+acc+`"]`, "100"))
+
+	// A reserve within the tolerance keeps minting
+	assert.Equal(t, "", reserveReport(token, tokenA, "ethereum", "995", "100"))
+	assert.Equal(t, "err_stale_reserve_report", reserveReport(token, tokenA, "ethereum", "1000", "100"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "100", "ethereum", tokenA, txHash(2), "1"))
+
+	// A shortfall beyond the tolerance pauses the mints until a healthy report
+	assert.Equal(t, "", reserveReport(token, tokenA, "ethereum", "1000", "101"))
+	assert.Equal(t, "err_reserve_shortfall", crossChainMintAtHeight(token, acc, "100", "ethereum", tokenA, txHash(3), "2"))
+	info := getCcTokenInfoByCache(token)
+	assert.Contains(t, info.Reserves, `"MintPaused":true`)
+	assert.Contains(t, info.Reserves, `"CollateralBps":"9090"`)
+
+	assert.Equal(t, "", reserveReport(token, tokenA, "ethereum", "1100", "102"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "100", "ethereum", tokenA, txHash(3), "2"))
+}
+
+func Test_Cc_Token_InvariantMonitor(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	tokenA := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, tokenA, "ethereum")
+	assert.Equal(t, "", setBurnFeeModels(token, `{"ethereum":{"Flat":"0"}}`))
+	crossChainMint(token, acc, "1000", "ethereum", tokenA, txHash(1))
+	assert.Equal(t, "err_alert_not_found", acknowledgeAlert(token, "ethereum"))
+
+	tokenB := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	registerSourceToken(token, tokenB, "bsc")
+	crossChainMint(token, acc, "100", "bsc", tokenB, txHash(2))
+
+	// A lock amount below the supply halts every chain type at once, the under-backed one included
+	assert.Equal(t, "", lockCorrection(token, "Adjust-Lock-Amount", map[string]string{
+		"SourceTokenId": tokenA, "LockAmount": "500", "Reason": "incident", "Reference": "INC-1",
+	}))
+	info := getCcTokenInfoByCache(token)
+	assert.Contains(t, info.HaltedChains, `"ethereum":{"ChainType":"ethereum","Invariant":"supply-backing"`)
+	assert.Contains(t, info.HaltedChains, `"bsc":{"ChainType":"bsc","Invariant":"supply-backing"`)
+	assert.Contains(t, info.HaltedChains, `"Actual":"600"`)
+	assert.Contains(t, info.HaltedChains, `"Expected":"1100"`)
+	assert.Equal(t, "err_chain_halted", crossChainMintAtHeight(token, acc, "100", "ethereum", tokenA, txHash(3), "1"))
+	assert.Equal(t, "err_chain_halted", tryCrossChainBurn(token, "200", tokenA))
+	assert.Equal(t, "err_chain_halted", crossChainMintAtHeight(token, acc, "100", "bsc", tokenB, txHash(4), "1"))
+	assert.Equal(t, "1100", getTotalSupplyByCache(token).String())
+
+	// The chain types resume once reconciled and acknowledged
+	assert.Equal(t, "", lockCorrection(token, "Adjust-Lock-Amount", map[string]string{
+		"SourceTokenId": tokenA, "LockAmount": "1000", "Reason": "reconciled", "Reference": "INC-1",
+	}))
+	assert.Equal(t, "", acknowledgeAlert(token, "ethereum"))
+	assert.Equal(t, "", acknowledgeAlert(token, "bsc"))
+	assert.Equal(t, "{}", getCcTokenInfoByCache(token).HaltedChains)
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "100", "ethereum", tokenA, txHash(3), "2"))
+}
+
+func Test_Cc_Token_BridgeLimits(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	tokenA := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	tokenB := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	registerSourceToken(token, tokenA, "ethereum")
+	registerSourceToken(token, tokenB, "bsc")
+	assert.Equal(t, "", setBurnFeeModels(token, `{"ethereum":{"Flat":"0"},"bsc":{"Flat":"0"}}`))
+
+	// Pausing a chain type leaves the others running
+	assert.Equal(t, "err_invalid_bridge_limit", setBridgeLimits(token, `{"ethereum":{"MaxPerTx":"-1"}}`))
+	assert.Equal(t, "", setBridgeLimits(token, `{"ethereum":{"MintPaused":true}}`))
+	assert.Equal(t, "err_mint_paused", crossChainMintAtHeight(token, acc, "1000", "ethereum", tokenA, txHash(1), "1"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "1000", "bsc", tokenB, txHash(2), "1"))
+
+	// Source token limits
+	assert.Equal(t, "", setBridgeLimits(token, `{"ethereum":null,"ethereum:`+tokenA+`":{"MaxPerTx":"500","DailyOutflowCap":"600"}}`))
+	assert.Equal(t, "err_exceeds_max_per_tx", crossChainMintAtHeight(token, acc, "600", "ethereum", tokenA, txHash(1), "1"))
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "500", "ethereum", tokenA, txHash(1), "1"))
+	assert.Equal(t, "", tryCrossChainBurn(token, "400", tokenA))
+	assert.Equal(t, "err_daily_cap_exceeded", tryCrossChainBurn(token, "300", tokenA))
+	assert.Contains(t, getCcTokenInfoByCache(token).BridgeUsage, `"ethereum:`+tokenA+`":{"Inflow":"0","Outflow":"400"}`)
+
+	// A refunded burn gives its outflow back
+	burnRefund(token, getBurnRecordsByCache(token)[0].BurnTxHash, "false")
+	assert.Equal(t, "", tryCrossChainBurn(token, "300", tokenA))
+	assert.Contains(t, getCcTokenInfoByCache(token).BridgeUsage, `"ethereum:`+tokenA+`":{"Inflow":"0","Outflow":"300"}`)
+
+	assert.Equal(t, "", setBridgeLimits(token, `{"bsc":{"BurnPaused":true}}`))
+	assert.Equal(t, "err_burn_paused", tryCrossChainBurn(token, "100", tokenB))
+}
+
+func Test_Cc_Token_ChainValidators(t *testing.T) {
+	token := crosschainToken(nameC, tickerC, decimalsC)
+	acc := hysdk.GetAddress()
+	btcToken := "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a"
+	registerSourceToken(token, btcToken, "bitcoin")
+	assert.Equal(t, "", setBurnFeeModels(token, `{"bitcoin":{"Flat":"0"}}`))
+
+	// Mint tx hashes are required, validated and normalized per source chain type
+	assert.Equal(t, "err_missing_mint_tx_hash", crossChainMintAtHeight(token, acc, "1000", "bitcoin", btcToken, "", ""))
+	assert.Equal(t, "err_invalid_mint_tx_hash", crossChainMintAtHeight(token, acc, "1000", "bitcoin", btcToken, "0x01", ""))
+	btcTxHash := "4A5E1E4BAAB89F3A32518A88C31BC87F618F76673E2CC77AB2127B7AFDEDA33B"
+	assert.Equal(t, "", crossChainMintAtHeight(token, acc, "1000", "bitcoin", btcToken, btcTxHash, ""))
+	assert.Equal(t, "err_repeat_mint", crossChainMintAtHeight(token, acc, "1000", "bitcoin", btcToken, strings.ToLower(btcTxHash), ""))
+	assert.Equal(t, "", getMintRecord(token, "bitcoin", btcTxHash))
+	assert.Equal(t, "err_mint_record_not_found", getMintRecord(token, "", btcTxHash))
+
+	// Burn recipients are validated and normalized per target chain type
+	assert.Equal(t, "err_invalid_recipient", crossChainBurnTo(token, "100", btcToken, acc))
+	assert.Equal(t, "", crossChainBurnTo(token, "100", btcToken, "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"))
+	records := getBurnRecordsByCache(token)
+	assert.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", records[0].Recipient)
+}
+
+func Test_Cc_Token_RestoreMintTxHashes(t *testing.T) {
+	acc := hysdk.GetAddress()
+	vm, err := crosschain.Spawn(vmmSchema.Env{Meta: vmmSchema.Meta{
+		AccId:  acc,
+		Params: map[string]string{"Name": nameC, "Ticker": tickerC, "Decimals": decimalsC},
+	}})
+	assert.NoError(t, err)
+	checkpoint, err := vm.Checkpoint()
+	assert.NoError(t, err)
+
+	// A checkpoint taken before the tx hashes were normalized, with an upper case hash
+	mintTxHash := txHash(0xabc)
+	snap := map[string]string{}
+	assert.NoError(t, json.Unmarshal([]byte(checkpoint), &snap))
+	cc := map[string]json.RawMessage{}
+	assert.NoError(t, json.Unmarshal([]byte(snap["cc"]), &cc))
+	cc["mintRecords"], _ = json.Marshal([]schema.MintRecord{
+		{MintTxHash: "0x" + strings.ToUpper(mintTxHash[2:]), SourceChainType: "ethereum"},
+	})
+	ccBy, _ := json.Marshal(cc)
+	snap["cc"] = string(ccBy)
+	snapBy, _ := json.Marshal(snap)
+	assert.NoError(t, vm.Restore(string(snapBy)))
+
+	// The restored hash is re-keyed, the canonical form is a replay
+	res := vm.Apply(acc, vmmSchema.Meta{
+		ItemId: "restore-mint",
+		Action: "Mint",
+		Params: map[string]string{
+			"Recipient":       acc,
+			"Quantity":        "100",
+			"SourceChainType": "ethereum",
+			"SourceTokenId":   "0x9f6d7a165C454008f2c8Bd72A21340b588F8C60a",
+			"X-MintTxHash":    mintTxHash,
+		},
+	})
+	assert.Equal(t, schema.ErrRepeatMint, res.Error)
+
+	checkpoint, err = vm.Checkpoint()
+	assert.NoError(t, err)
+	assert.Contains(t, checkpoint, mintTxHash)
+	assert.NotContains(t, checkpoint, strings.ToUpper(mintTxHash[2:]))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/aox-labs/hymx-vmtoken/basic"
 	"github.com/aox-labs/hymx-vmtoken/crosschain"
+	"github.com/aox-labs/hymx-vmtoken/rebase"
 	"github.com/aox-labs/hymx-vmtoken/schema"
 	"github.com/everFinance/goether"
 	"github.com/gin-gonic/gin"
@@ -101,6 +102,7 @@ func initNode() {
 	// mount your vm here.....
 	s.Mount(schema.VmTokenBasicModuleFormat, basic.Spawn)
 	s.Mount(schema.VmTokenCrossChainMultiModuleFormat, crosschain.Spawn)
+	s.Mount(schema.VmTokenRebaseModuleFormat, rebase.Spawn)
 
 	s.Run(port)
 }
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	nameR     = "r token"
+	tickerR   = "rToken"
+	decimalsR = "18"
+	rToken    string
+)
+
+func init() {
+	rToken = rebaseToken(nameR, tickerR, decimalsR, maxSupply)
+	tokenInfo(rToken)
+}
+
+func Test_Rebase_Token_Info(t *testing.T) {
+	info := getRebaseTokenInfoByCache(rToken)
+	assert.Equal(t, nameR, info.Name)
+	assert.Equal(t, tickerR, info.Ticker)
+	assert.Equal(t, decimalsR, info.Decimals)
+	assert.Equal(t, hysdk.Bundler.Address, info.Rebaser)
+	assert.Equal(t, "1000000000000000000", info.Index)
+}
+
+func Test_Rebase_Token_Rebase(t *testing.T) {
+	acc := hysdk.GetAddress()
+	basicTokenMint(rToken, acc, "1000")
+
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	transfer(rToken, addr01, "400")
+
+	// Double the supply
+	rebaseIndex(rToken, "2000000000000000000")
+
+	assert.Equal(t, big.NewInt(1200), getBalanceByCache(rToken, acc))
+	assert.Equal(t, big.NewInt(800), getBalanceByCache(rToken, addr01))
+	assert.Equal(t, big.NewInt(2000), getTotalSupplyByCache(rToken))
+
+	// Transfers move rebased amounts
+	transfer(rToken, addr01, "200")
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(rToken, acc))
+	assert.Equal(t, big.NewInt(1000), getBalanceByCache(rToken, addr01))
+}
+
+func Test_Rebase_Token_TransferAtIndex(t *testing.T) {
+	token := rebaseToken(nameR, tickerR, decimalsR, maxSupply)
+	acc := hysdk.GetAddress()
+	basicTokenMint(token, acc, "1000")
+
+	// A share is worth three units, transfers still move exactly their quantity
+	rebaseIndex(token, "3000000000000000000")
+	assert.Equal(t, big.NewInt(3000), getBalanceByCache(token, acc))
+
+	addr01 := "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f97"
+	for _, quantity := range []int64{1, 7, 1000} {
+		senderBal := getBalanceByCache(token, acc)
+		recipientBal := getBalanceByCache(token, addr01)
+		transfer(token, addr01, big.NewInt(quantity).String())
+
+		assert.Equal(t, new(big.Int).Sub(senderBal, big.NewInt(quantity)), getBalanceByCache(token, acc))
+		assert.Equal(t, new(big.Int).Add(recipientBal, big.NewInt(quantity)), getBalanceByCache(token, addr01))
+	}
+	total := new(big.Int).Add(getBalanceByCache(token, acc), getBalanceByCache(token, addr01))
+	assert.Equal(t, big.NewInt(3000), total)
+	assert.Equal(t, big.NewInt(3000), getTotalSupplyByCache(token))
+}
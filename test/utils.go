@@ -14,11 +14,28 @@ var (
 	TAxModule      = "1i03Vpe8DljkUMBEEEvR0VmbJjvgZtP_ytZdThkVSMw"
 	RegistryModule = "MVTil0kn5SRiJELW7W2jLZ6cBr3QUGj1nJ67I2Wi4Ps"
 
-	BasicTokenMod = "9bQh650l10NZ7GHUvj1L_kIIiivp9Zj7kJNY3CLEcRM" // Token token module format
-	CcTokenMod    = "PxAJFkNuJqcRKB6475tqqT2R0G1OT-0KDcqHMbejV84" // Cross-chain token module format
+	BasicTokenMod  = "9bQh650l10NZ7GHUvj1L_kIIiivp9Zj7kJNY3CLEcRM" // Token token module format
+	CcTokenMod     = "PxAJFkNuJqcRKB6475tqqT2R0G1OT-0KDcqHMbejV84" // Cross-chain token module format
+	RebaseTokenMod = "fY0ZmAJ0ArGCCXPzFYMjZDk6ytFjB4Mgok6XhA0lMaA" // Rebasing token module format
 
 )
 
+// sendAction sends the tags to a token and returns the vm error
+func sendAction(tokenId string, tags []goarSchema.Tag) string {
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// mustSendAction sends the tags to a token and panics on a vm error
+func mustSendAction(tokenId string, tags []goarSchema.Tag) {
+	if vmErr := sendAction(tokenId, tags); vmErr != "" {
+		panic(vmErr)
+	}
+}
+
 func basicToken(name, symbol, decimals, maxSupply string) string {
 	res, err := hysdk.SpawnAndWait(BasicTokenMod, nodeInfo.Node.AccId,
 		[]goarSchema.Tag{
@@ -50,6 +67,20 @@ func crosschainToken(name, symbol, decimals string) string {
 	return res.Id
 }
 
+func rebaseToken(name, symbol, decimals, maxSupply string) string {
+	res, err := hysdk.SpawnAndWait(RebaseTokenMod, nodeInfo.Node.AccId,
+		[]goarSchema.Tag{
+			{Name: "Name", Value: name},
+			{Name: "Ticker", Value: symbol},
+			{Name: "Decimals", Value: decimals},
+			{Name: "MaxSupply", Value: maxSupply},
+		})
+	if err != nil {
+		panic(err)
+	}
+	return res.Id
+}
+
 func tokenInfo(tokenId string) {
 	tags := []goarSchema.Tag{
 		{Name: "Action", Value: "Info"},
@@ -289,3 +320,824 @@ func setCcTokenParams(tokenId, burnFee, feeRecipient, burnProcessor, name string
 		panic(vmErr)
 	}
 }
+
+func approveHold(tokenId, merchant, quantity string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Approve-Hold"},
+		{Name: "Merchant", Value: merchant},
+		{Name: "Quantity", Value: quantity},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// placeHold places a hold on account and returns the hold id
+func placeHold(tokenId, account, quantity string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Hold"},
+		{Name: "Account", Value: account},
+		{Name: "Quantity", Value: quantity},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+	return resp.Id
+}
+
+// balanceTags returns the tags of a Balance reply
+func balanceTags(tokenId, account string) map[string]string {
+	return replyTags(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Balance"},
+		{Name: "Recipient", Value: account},
+	})
+}
+
+// tryTransfer transfers and returns the vm error
+func tryTransfer(tokenId, to, amt string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Transfer"},
+		{Name: "Recipient", Value: to},
+		{Name: "Quantity", Value: amt},
+	})
+}
+
+func captureHold(tokenId, holdId, quantity, recipient string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Capture-Hold"},
+		{Name: "HoldId", Value: holdId},
+		{Name: "Quantity", Value: quantity},
+		{Name: "Recipient", Value: recipient},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func freezeAccount(tokenId, account, reason string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Freeze-Account"},
+		{Name: "Account", Value: account},
+		{Name: "Reason", Value: reason},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func forceTransfer(tokenId, account, recipient, quantity, reason string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Force-Transfer"},
+		{Name: "Account", Value: account},
+		{Name: "Recipient", Value: recipient},
+		{Name: "Quantity", Value: quantity},
+		{Name: "Reason", Value: reason},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func getFrozenByCache(tokenId string) []string {
+	frozenJs, err := hysdk.Client.GetCache(tokenId, "frozen")
+	if err != nil {
+		panic(fmt.Sprintf("failed to get frozen accounts: %v", err))
+	}
+	var frozen []string
+	if err = json.Unmarshal([]byte(frozenJs), &frozen); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal frozen accounts: %v", err))
+	}
+	return frozen
+}
+
+func setPolicy(tokenId, policy string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Policy"},
+		{Name: "Policy", Value: policy},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func getPolicyByCache(tokenId string) schema.Policy {
+	policyJs, err := hysdk.Client.GetCache(tokenId, "policy")
+	if err != nil {
+		panic(fmt.Sprintf("failed to get policy: %v", err))
+	}
+	policy := schema.Policy{}
+	if err = json.Unmarshal([]byte(policyJs), &policy); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal policy: %v", err))
+	}
+	return policy
+}
+
+func rebaseIndex(tokenId, index string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Rebase"},
+		{Name: "Index", Value: index},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func getRebaseTokenInfoByCache(tokenId string) schema.RebaseCacheInfo {
+	infoJs, err := hysdk.Client.GetCache(tokenId, "info")
+	if err != nil {
+		panic(fmt.Sprintf("failed to get info: %v", err))
+	}
+
+	info := schema.RebaseCacheInfo{}
+	if err = json.Unmarshal([]byte(infoJs), &info); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal infoJs: %v", err))
+	}
+
+	return info
+}
+
+func redenominate(tokenId, numerator, denominator, decimals string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Redenominate"},
+		{Name: "Numerator", Value: numerator},
+		{Name: "Denominator", Value: denominator},
+		{Name: "Decimals", Value: decimals},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// setRedenominateMultisig sets the redenomination signers and threshold and returns the vm error
+func setRedenominateMultisig(tokenId, signers, threshold string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "RedenominateSigners", Value: signers},
+		{Name: "RedenominateThreshold", Value: threshold},
+	})
+}
+
+// proposeRedenomination sends a Redenominate behind the multisig and returns the proposal id
+func proposeRedenomination(tokenId, numerator, denominator string) string {
+	return replyTags(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Redenominate"},
+		{Name: "Numerator", Value: numerator},
+		{Name: "Denominator", Value: denominator},
+	})["ProposalId"]
+}
+
+// approveRedenomination approves a redenomination proposal and returns the vm error
+func approveRedenomination(tokenId, proposalId string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Approve-Redenominate"},
+		{Name: "ProposalId", Value: proposalId},
+	})
+}
+
+func distribute(tokenId, quantity string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Distribute"},
+		{Name: "Quantity", Value: quantity},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// setRewardTokens sets the allowed reward tokens and returns the vm error
+func setRewardTokens(tokenId, rewardTokens string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Reward-Tokens"},
+		{Name: "RewardTokens", Value: rewardTokens},
+	})
+}
+
+// distributeToken transfers a pool of rewardToken to tokenId to be distributed there
+func distributeToken(rewardToken, tokenId, quantity string) {
+	mustSendAction(rewardToken, []goarSchema.Tag{
+		{Name: "Action", Value: "Transfer"},
+		{Name: "Recipient", Value: tokenId},
+		{Name: "Quantity", Value: quantity},
+		{Name: "X-Action", Value: "Distribute"},
+	})
+}
+
+func claimRewards(tokenId, rewardToken string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Claim-Rewards"},
+		{Name: "RewardToken", Value: rewardToken},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func twab(tokenId, action, account, start string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: action},
+		{Name: "Account", Value: account},
+		{Name: "Start", Value: start},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func setEmission(tokenId, emission string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Emission"},
+		{Name: "Emission", Value: emission},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func emit(tokenId string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Emit"},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+func getEmissionByCache(tokenId string) schema.EmissionCacheInfo {
+	emissionJs, err := hysdk.Client.GetCache(tokenId, "emission")
+	if err != nil {
+		panic(fmt.Sprintf("failed to get emission: %v", err))
+	}
+	emission := schema.EmissionCacheInfo{}
+	if err = json.Unmarshal([]byte(emissionJs), &emission); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal emission: %v", err))
+	}
+	return emission
+}
+
+func transferWithIdempotencyKey(tokenId, to, amt, key string) {
+	if vmErr := tryTransferWithIdempotencyKey(tokenId, to, amt, key); vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// tryTransferWithIdempotencyKey transfers with an idempotency key and returns the vm error
+func tryTransferWithIdempotencyKey(tokenId, to, amt, key string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Transfer"},
+		{Name: "Recipient", Value: to},
+		{Name: "Quantity", Value: amt},
+		{Name: "X-Idempotency-Key", Value: key},
+	})
+}
+
+func setMintRecordRetention(tokenId, maxAge, maxBlocks string) {
+	if vmErr := setMintRecordRetentionErr(tokenId, maxAge, maxBlocks); vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// setMintRecordRetentionErr sets the mint record retention and returns the vm error
+func setMintRecordRetentionErr(tokenId, maxAge, maxBlocks string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "MintRecordMaxAge", Value: maxAge},
+		{Name: "MintRecordMaxBlocks", Value: maxBlocks},
+	})
+}
+
+// resetMintWatermark lowers the mint watermark of a chain type and returns the vm error
+func resetMintWatermark(tokenId, chainType, blockHeight string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Reset-Mint-Watermark"},
+		{Name: "ChainType", Value: chainType},
+		{Name: "BlockHeight", Value: blockHeight},
+	})
+}
+
+func setBurnRecordMaxAge(tokenId, maxAge string) {
+	mustSendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "BurnRecordMaxAge", Value: maxAge},
+	})
+}
+
+// crossChainMintAtHeight mints with a source chain block height and returns the vm error
+func crossChainMintAtHeight(tokenId, recipient, quantity, sourceChainType, sourceTokenId, mintTxHash, blockHeight string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Mint"},
+		{Name: "Recipient", Value: recipient},
+		{Name: "Quantity", Value: quantity},
+		{Name: "SourceChainType", Value: sourceChainType},
+		{Name: "SourceTokenId", Value: sourceTokenId},
+		{Name: "X-MintTxHash", Value: mintTxHash},
+		{Name: "X-BlockHeight", Value: blockHeight},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// getMintRecord queries the record of a mint tx hash of a chain type and returns the vm error
+func getMintRecord(tokenId, chainType, mintTxHash string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Mint-Record"},
+		{Name: "ChainType", Value: chainType},
+		{Name: "MintTxHash", Value: mintTxHash},
+	})
+}
+
+func getMintRecordsByCache(tokenId string) []schema.MintRecord {
+	recordsJs, err := hysdk.Client.GetCache(tokenId, "mint-records")
+	if err != nil {
+		panic(fmt.Sprintf("failed to get mint records: %v", err))
+	}
+	records := []schema.MintRecord{}
+	if err = json.Unmarshal([]byte(recordsJs), &records); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal mint records: %v", err))
+	}
+	return records
+}
+
+func getBurnRecordsByCache(tokenId string) []schema.BurnRecord {
+	recordsJs, err := hysdk.Client.GetCache(tokenId, "burn-records")
+	if err != nil {
+		panic(fmt.Sprintf("failed to get burn records: %v", err))
+	}
+	records := []schema.BurnRecord{}
+	if err = json.Unmarshal([]byte(recordsJs), &records); err != nil {
+		panic(fmt.Sprintf("failed to unmarshal burn records: %v", err))
+	}
+	return records
+}
+
+func burnComplete(tokenId, burnTxHash, targetTxHash string) {
+	if vmErr := tryBurnComplete(tokenId, burnTxHash, targetTxHash); vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// tryBurnComplete completes a burn and returns the vm error
+func tryBurnComplete(tokenId, burnTxHash, targetTxHash string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Burn-Complete"},
+		{Name: "BurnTxHash", Value: burnTxHash},
+		{Name: "TargetTxHash", Value: targetTxHash},
+	})
+}
+
+func burnRefund(tokenId, burnTxHash, refundFee string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Burn-Refund"},
+		{Name: "BurnTxHash", Value: burnTxHash},
+		{Name: "RefundFee", Value: refundFee},
+		{Name: "Reason", Value: "target chain unavailable"},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// registerSourceToken registers a source token, a token registered by an earlier test is kept
+func registerSourceToken(tokenId, sourceTokenId, sourceChainType string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Register-Source-Token"},
+		{Name: "SourceTokenId", Value: sourceTokenId},
+		{Name: "SourceChainType", Value: sourceChainType},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" && vmErr != "err_source_token_registered" {
+		panic(vmErr)
+	}
+}
+
+// sourceTokenAction sends a source token registry action and returns the vm error
+func sourceTokenAction(tokenId, action, sourceTokenId, sourceChainType string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: action},
+		{Name: "SourceTokenId", Value: sourceTokenId},
+		{Name: "SourceChainType", Value: sourceChainType},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+func registerSourceTokenWithDecimals(tokenId, sourceTokenId, sourceChainType, decimals string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Register-Source-Token"},
+		{Name: "SourceTokenId", Value: sourceTokenId},
+		{Name: "SourceChainType", Value: sourceChainType},
+		{Name: "Decimals", Value: decimals},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// setCcTokenDecimals changes the wrapped token decimals and returns the vm error
+func setCcTokenDecimals(tokenId, decimals string) string {
+	return sendAction(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "Decimals", Value: decimals},
+	})
+}
+
+func setDustPolicy(tokenId, dustPolicy string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "DustPolicy", Value: dustPolicy},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// tryCrossChainBurn performs a cross-chain burn and returns the vm error
+func tryCrossChainBurn(tokenId, quantity, targetTokenId string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Burn"},
+		{Name: "Quantity", Value: quantity},
+		{Name: "TargetTokenId", Value: targetTokenId},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+func setRelayers(tokenId, relayers, threshold string) {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "Relayers", Value: relayers},
+		{Name: "RelayerThreshold", Value: threshold},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	vmErr := gjson.Get(resp.Message, "Error").Str
+	if vmErr != "" {
+		panic(vmErr)
+	}
+}
+
+// attestMint sends a relayer attestation and returns the vm error
+func attestMint(tokenId, recipient, quantity, sourceChainType, sourceTokenId, mintTxHash string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Attest-Mint"},
+		{Name: "Recipient", Value: recipient},
+		{Name: "Quantity", Value: quantity},
+		{Name: "SourceChainType", Value: sourceChainType},
+		{Name: "SourceTokenId", Value: sourceTokenId},
+		{Name: "X-MintTxHash", Value: mintTxHash},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// setBurnFeeModels sets the burn fee models and returns the vm error
+func setBurnFeeModels(tokenId, burnFeeModels string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "BurnFeeModels", Value: burnFeeModels},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// quoteBurn returns the tags of a Quote-Burn reply
+func quoteBurn(tokenId, quantity, targetTokenId string) map[string]string {
+	return replyTags(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Quote-Burn"},
+		{Name: "Quantity", Value: quantity},
+		{Name: "TargetTokenId", Value: targetTokenId},
+	})
+}
+
+// quoteBurnToChain returns the tags of a Quote-Burn reply routed to a chain type
+func quoteBurnToChain(tokenId, quantity, targetChainType string) map[string]string {
+	return replyTags(tokenId, []goarSchema.Tag{
+		{Name: "Action", Value: "Quote-Burn"},
+		{Name: "Quantity", Value: quantity},
+		{Name: "TargetChainType", Value: targetChainType},
+	})
+}
+
+// replyTags sends the tags to a token and returns the tags of its first reply
+func replyTags(tokenId string, tags []goarSchema.Tag) map[string]string {
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	quote := make(map[string]string)
+	for _, tag := range gjson.Get(resp.Message, "Messages.0.Tags").Array() {
+		quote[tag.Get("name").Str] = tag.Get("value").Str
+	}
+	return quote
+}
+
+// setMintFeeModels sets the mint fee models and returns the vm error
+func setMintFeeModels(tokenId, mintFeeModels string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "MintFeeModels", Value: mintFeeModels},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// getAvailableFeesByCache returns the fees of a chain type that can be withdrawn
+func getAvailableFeesByCache(tokenId, chainType string) *big.Int {
+	feesJson, err := hysdk.Client.GetCache(tokenId, "fees")
+	if err != nil {
+		panic(err)
+	}
+	for _, fees := range gjson.Parse(feesJson).Array() {
+		if fees.Get("ChainType").Str == chainType {
+			return mustParseBigInt(fees.Get("Available").Str)
+		}
+	}
+	return big.NewInt(0)
+}
+
+// setFeeShares sets the fee withdrawal shares and returns the vm error
+func setFeeShares(tokenId, feeShares string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "FeeShares", Value: feeShares},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// withdrawFees withdraws the fees and returns the vm error
+func withdrawFees(tokenId, chainType, quantity string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Withdraw-Fees"},
+	}
+	if chainType != "" {
+		tags = append(tags, goarSchema.Tag{Name: "ChainType", Value: chainType})
+	}
+	if quantity != "" {
+		tags = append(tags, goarSchema.Tag{Name: "Quantity", Value: quantity})
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// burnToChain burns to a source token of a chain type picked by its burn route and returns the vm error
+func burnToChain(tokenId, quantity, targetChainType string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Burn"},
+		{Name: "Quantity", Value: quantity},
+		{Name: "TargetChainType", Value: targetChainType},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// setBurnRoutes sets the burn routes and returns the vm error
+func setBurnRoutes(tokenId, burnRoutes string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "BurnRoutes", Value: burnRoutes},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// lockCorrection sends Adjust-Lock-Amount or Move-Lock-Amount and returns the vm error
+func lockCorrection(tokenId, action string, params map[string]string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: action},
+	}
+	for name, value := range params {
+		tags = append(tags, goarSchema.Tag{Name: name, Value: value})
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// setReserveAttestors sets the reserve attestors and the shortfall tolerance and returns the vm error
+func setReserveAttestors(tokenId, attestors, tolerance string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Params"},
+		{Name: "ReserveAttestors", Value: attestors},
+		{Name: "ReserveTolerance", Value: tolerance},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// reserveReport reports the reserve of a source token at a block height and returns the vm error
+func reserveReport(tokenId, sourceTokenId, sourceChainType, reserve, blockHeight string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Reserve-Report"},
+		{Name: "SourceTokenId", Value: sourceTokenId},
+		{Name: "SourceChainType", Value: sourceChainType},
+		{Name: "Reserve", Value: reserve},
+		{Name: "BlockHeight", Value: blockHeight},
+		{Name: "Timestamp", Value: "1700000000"},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// acknowledgeAlert resumes a chain type halted by an invariant alert and returns the vm error
+func acknowledgeAlert(tokenId, chainType string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Acknowledge-Alert"},
+		{Name: "ChainType", Value: chainType},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// setBridgeLimits merges the bridge limits and returns the vm error
+func setBridgeLimits(tokenId, bridgeLimits string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Set-Bridge-Limits"},
+		{Name: "BridgeLimits", Value: bridgeLimits},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
+
+// txHash returns the n-th EVM transaction hash of the tests
+func txHash(n int) string {
+	return fmt.Sprintf("0x%064x", n)
+}
+
+// crossChainBurnTo burns to a recipient on a target token and returns the vm error
+func crossChainBurnTo(tokenId, quantity, targetTokenId, recipient string) string {
+	tags := []goarSchema.Tag{
+		{Name: "Action", Value: "Burn"},
+		{Name: "Quantity", Value: quantity},
+		{Name: "TargetTokenId", Value: targetTokenId},
+		{Name: "Recipient", Value: recipient},
+	}
+
+	resp, err := hysdk.SendMessageAndWait(tokenId, "", tags)
+	if err != nil {
+		panic(err)
+	}
+	return gjson.Get(resp.Message, "Error").Str
+}
@@ -10,4 +10,5 @@ func Test_Generate_Module(t *testing.T) {
 
 	generateModule(schema.VmTokenBasicModuleFormat)           // mod-9bQh650l10NZ7GHUvj1L_kIIiivp9Zj7kJNY3CLEcRM.json
 	generateModule(schema.VmTokenCrossChainMultiModuleFormat) // mod-PxAJFkNuJqcRKB6475tqqT2R0G1OT-0KDcqHMbejV84.json
+	generateModule(schema.VmTokenRebaseModuleFormat)          // mod-fY0ZmAJ0ArGCCXPzFYMjZDk6ytFjB4Mgok6XhA0lMaA.json
 }